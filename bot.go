@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-telegram/bot/models"
@@ -18,6 +19,11 @@ const (
 	defaultUpdatesChanCap   = 1024
 	defaultCheckInitTimeout = time.Second * 5
 	defaultWorkers          = 1
+
+	// file size limits of the Bot API, see https://core.telegram.org/bots/api#using-a-local-bot-api-server
+	cloudMaxUploadSize   = 50 << 20
+	cloudMaxDownloadSize = 20 << 20
+	localMaxUploadSize   = 2000 << 20
 )
 
 type HttpClient interface {
@@ -26,27 +32,45 @@ type HttpClient interface {
 
 type ErrorsHandler func(err error)
 type DebugHandler func(format string, args ...any)
+type UploadProgressHandler func(progress UploadProgress)
+type ConflictHandler func(err error)
 type Middleware func(next HandlerFunc) HandlerFunc
 type HandlerFunc func(ctx context.Context, bot *Bot, update *models.Update)
 type MatchFunc func(update *models.Update) bool
 
 // Bot represents Telegram Bot main object
 type Bot struct {
-	lastUpdateID int64
+	lastUpdateID     int64
+	duplicateUpdates int64
+	handlersInFlight int64
 
 	url                string
 	token              string
 	pollTimeout        time.Duration
 	skipGetMe          bool
 	webhookSecretToken string
-	testEnvironment    bool
-	workers            int
-	notAsyncHandlers   bool
+
+	webhookMaxBodySize       int64
+	webhookIPCheck           bool
+	webhookTrustForwardedFor bool
+	syncWebhook              bool
+	stopPollingOnConflict    bool
+	validateParams           bool
+
+	testEnvironment  bool
+	localMode        bool
+	fileSizeLimits   bool
+	maxUploadSize    int64
+	maxDownloadSize  int64
+	workers          int
+	notAsyncHandlers bool
 
 	defaultHandlerFunc HandlerFunc
 
-	errorsHandler ErrorsHandler
-	debugHandler  DebugHandler
+	errorsHandler         ErrorsHandler
+	debugHandler          DebugHandler
+	uploadProgressHandler UploadProgressHandler
+	conflictHandler       ConflictHandler
 
 	middlewares []Middleware
 
@@ -54,12 +78,33 @@ type Bot struct {
 	handlers   []handler
 
 	client           HttpClient
+	pollClient       HttpClient
+	downloadClient   HttpClient
+	fileIDCache      FileIDCache
+	dedupStore       DedupStore
+	diskQueue        *DiskQueue
+	retryPolicy      RetryPolicy
+	deadLetterSink   DeadLetterSink
+	leaderElector    LeaderElector
+	metrics          *Metrics
+	tracer           Tracer
+	structuredLogger structuredLogger
+	requestEncoding  RequestEncoding
 	isDebug          bool
 	checkInitTimeout time.Duration
 
+	apiRequestTimeout     time.Duration
+	uploadTimeout         time.Duration
+	downloadTimeout       time.Duration
+	methodTimeouts        map[string]time.Duration
+	connectionPool        ConnectionPool
+	pollingConnectionPool ConnectionPool
+
 	allowedUpdates AllowedUpdates
 
 	updates chan *models.Update
+
+	status botStatus
 }
 
 // New creates new Bot instance
@@ -69,17 +114,19 @@ func New(token string, options ...Option) (*Bot, error) {
 	}
 
 	b := &Bot{
-		url:         "https://api.telegram.org",
-		token:       token,
-		pollTimeout: defaultPollTimeout,
-		client: &http.Client{
-			Timeout: defaultPollTimeout,
-		},
+		url:                "https://api.telegram.org",
+		token:              token,
+		pollTimeout:        defaultPollTimeout,
 		defaultHandlerFunc: defaultHandler,
 		errorsHandler:      defaultErrorsHandler,
 		debugHandler:       defaultDebugHandler,
 		checkInitTimeout:   defaultCheckInitTimeout,
 		workers:            defaultWorkers,
+		webhookMaxBodySize: defaultWebhookMaxBodySize,
+		apiRequestTimeout:  defaultRequestTimeout,
+		uploadTimeout:      defaultUploadTimeout,
+		downloadTimeout:    defaultDownloadTimeout,
+		methodTimeouts:     map[string]time.Duration{},
 
 		updates: make(chan *models.Update, defaultUpdatesChanCap),
 	}
@@ -88,6 +135,8 @@ func New(token string, options ...Option) (*Bot, error) {
 		o(b)
 	}
 
+	b.initHTTPClients()
+
 	ctx, cancel := context.WithTimeout(context.Background(), b.checkInitTimeout)
 	defer cancel()
 
@@ -123,8 +172,16 @@ func (b *Bot) Token() string {
 
 // StartWebhook starts the Bot with webhook mode
 func (b *Bot) StartWebhook(ctx context.Context) {
+	b.setRunning(true)
+	defer b.setRunning(false)
+
 	wg := sync.WaitGroup{}
 
+	if b.diskQueue != nil {
+		wg.Add(1)
+		go b.feedDiskQueue(ctx, &wg)
+	}
+
 	wg.Add(b.workers)
 	for i := 0; i < b.workers; i++ {
 		go b.waitUpdates(ctx, &wg)
@@ -135,8 +192,19 @@ func (b *Bot) StartWebhook(ctx context.Context) {
 
 // Start the bot
 func (b *Bot) Start(ctx context.Context) {
+	b.setRunning(true)
+	defer b.setRunning(false)
+
 	wg := sync.WaitGroup{}
 
+	if b.diskQueue != nil {
+		if lastUpdateID := b.diskQueue.LastUpdateID(); lastUpdateID > atomic.LoadInt64(&b.lastUpdateID) {
+			atomic.StoreInt64(&b.lastUpdateID, lastUpdateID)
+		}
+		wg.Add(1)
+		go b.feedDiskQueue(ctx, &wg)
+	}
+
 	wg.Add(1)
 	go b.getUpdates(ctx, &wg)
 
@@ -161,7 +229,9 @@ func defaultHandler(_ context.Context, _ *Bot, update *models.Update) {
 }
 
 func (b *Bot) error(format string, args ...interface{}) {
-	b.errorsHandler(fmt.Errorf(format, args...))
+	err := fmt.Errorf(format, args...)
+	b.setLastError(err)
+	b.errorsHandler(err)
 }
 
 // True and False returns the pointer to bool
@@ -204,7 +204,7 @@ func TestBot_StartWebhookWithCorrectSecret(t *testing.T) {
 	}
 	req.Header.Set("X-Telegram-Bot-Api-Secret-Token", "zzzz")
 
-	b.WebhookHandler().ServeHTTP(nil, req)
+	b.WebhookHandler().ServeHTTP(httptest.NewRecorder(), req)
 
 	cancel()
 
@@ -252,7 +252,7 @@ func TestBot_StartWebhookWithNoSecret(t *testing.T) {
 		return
 	}
 
-	b.WebhookHandler().ServeHTTP(nil, req)
+	b.WebhookHandler().ServeHTTP(httptest.NewRecorder(), req)
 
 	cancel()
 
@@ -301,7 +301,7 @@ func TestBot_StartWebhookWithWrongSecret(t *testing.T) {
 	}
 	req.Header.Set("X-Telegram-Bot-Api-Secret-Token", "wrong_secret")
 
-	b.WebhookHandler().ServeHTTP(nil, req)
+	b.WebhookHandler().ServeHTTP(httptest.NewRecorder(), req)
 
 	cancel()
 
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"io"
 	"mime/multipart"
+	"os"
 	"reflect"
 	"strings"
 
@@ -24,9 +25,14 @@ type customMarshal interface {
 var customMarshalInterface = reflect.TypeOf(new(customMarshal)).Elem()
 var inputMediaInterface = reflect.TypeOf(new(inputMedia)).Elem()
 
+// fileProgressFunc is called while file content is written to the form
+// total is 0 if the file size is unknown
+type fileProgressFunc func(fieldName, filename string, uploaded, total int64)
+
 // buildRequestForm builds form-data for request
 // if params contains InputFile of type InputFileUpload, it will be added to form-data ad upload file. Also, for InputMedia attachments
-func buildRequestForm(form *multipart.Writer, params any) (int, error) {
+// progress may be nil
+func buildRequestForm(form *multipart.Writer, params any, progress fileProgressFunc) (int, error) {
 	v := reflect.ValueOf(params).Elem()
 
 	var fieldsCount int
@@ -52,7 +58,7 @@ func buildRequestForm(form *multipart.Writer, params any) (int, error) {
 			continue
 		}
 		if v.Field(i).Type().Implements(inputMediaInterface) {
-			err := addFormFieldInputMedia(form, fieldName, v.Field(i).Interface().(inputMedia))
+			err := addFormFieldInputMedia(form, fieldName, v.Field(i).Interface().(inputMedia), progress)
 			if err != nil {
 				return 0, err
 			}
@@ -66,7 +72,7 @@ func buildRequestForm(form *multipart.Writer, params any) (int, error) {
 		case string:
 			err = addFormFieldString(form, fieldName, vv)
 		case *models.InputFileUpload:
-			err = addFormFieldInputFileUpload(form, fieldName, vv)
+			err = addFormFieldInputFileUpload(form, fieldName, vv, progress)
 		case *models.InputFileString:
 			err = addFormFieldString(form, fieldName, vv.Data)
 		case []models.InputMedia:
@@ -74,13 +80,13 @@ func buildRequestForm(form *multipart.Writer, params any) (int, error) {
 			for _, m := range vv {
 				ss = append(ss, m)
 			}
-			err = addFormFieldInputMediaSlice(form, fieldName, ss)
+			err = addFormFieldInputMediaSlice(form, fieldName, ss, progress)
 		case []models.InputPaidMedia:
 			var ss []inputMedia
 			for _, m := range vv {
 				ss = append(ss, m)
 			}
-			err = addFormFieldInputMediaSlice(form, fieldName, ss)
+			err = addFormFieldInputMediaSlice(form, fieldName, ss, progress)
 		case []models.InlineQueryResult:
 			err = addFormFieldInlineQueryResultSlice(form, fieldName, vv)
 		default:
@@ -96,23 +102,62 @@ func buildRequestForm(form *multipart.Writer, params any) (int, error) {
 	return fieldsCount, nil
 }
 
-func addFormFieldInputFileUpload(form *multipart.Writer, fieldName string, value *models.InputFileUpload) error {
+func addFormFieldInputFileUpload(form *multipart.Writer, fieldName string, value *models.InputFileUpload, progress fileProgressFunc) error {
 	w, errCreateField := form.CreateFormFile(fieldName, value.Filename)
 	if errCreateField != nil {
 		return errCreateField
 	}
-	_, errCopy := io.Copy(w, value.Data)
+	return copyFormFile(w, value.Data, fieldName, value.Filename, progress)
+}
+
+func copyFormFile(w io.Writer, r io.Reader, fieldName, filename string, progress fileProgressFunc) error {
+	if progress != nil {
+		w = &progressWriter{w: w, fieldName: fieldName, filename: filename, total: readerSize(r), progress: progress}
+	}
+	_, errCopy := io.Copy(w, r)
 	return errCopy
 }
 
-func addFormFieldInputMediaItem(form *multipart.Writer, value inputMedia) ([]byte, error) {
+// progressWriter reports the number of bytes written to the underlying writer
+type progressWriter struct {
+	w         io.Writer
+	fieldName string
+	filename  string
+	uploaded  int64
+	total     int64
+	progress  fileProgressFunc
+}
+
+func (p *progressWriter) Write(data []byte) (int, error) {
+	n, err := p.w.Write(data)
+	p.uploaded += int64(n)
+	p.progress(p.fieldName, p.filename, p.uploaded, p.total)
+	return n, err
+}
+
+// readerSize returns the size of the reader content if it can be determined without reading, otherwise 0
+func readerSize(r io.Reader) int64 {
+	switch rr := r.(type) {
+	case interface{ Len() int }:
+		return int64(rr.Len())
+	case interface{ Stat() (os.FileInfo, error) }:
+		info, err := rr.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			return 0
+		}
+		return info.Size()
+	}
+	return 0
+}
+
+func addFormFieldInputMediaItem(form *multipart.Writer, value inputMedia, progress fileProgressFunc) ([]byte, error) {
 	if strings.HasPrefix(value.GetMedia(), "attach://") {
 		filename := strings.TrimPrefix(value.GetMedia(), "attach://")
 		mediaAttachmentField, errCreateMediaAttachmentField := form.CreateFormFile(filename, filename)
 		if errCreateMediaAttachmentField != nil {
 			return nil, errCreateMediaAttachmentField
 		}
-		_, errCopy := io.Copy(mediaAttachmentField, value.Attachment())
+		errCopy := copyFormFile(mediaAttachmentField, value.Attachment(), filename, filename, progress)
 		if errCopy != nil {
 			return nil, errCopy
 		}
@@ -133,8 +178,8 @@ func addFormFieldCustomMarshal(form *multipart.Writer, fieldName string, value c
 	return errCopy
 }
 
-func addFormFieldInputMedia(form *multipart.Writer, fieldName string, value inputMedia) error {
-	line, err := addFormFieldInputMediaItem(form, value)
+func addFormFieldInputMedia(form *multipart.Writer, fieldName string, value inputMedia, progress fileProgressFunc) error {
+	line, err := addFormFieldInputMediaItem(form, value, progress)
 	if err != nil {
 		return err
 	}
@@ -147,10 +192,10 @@ func addFormFieldInputMedia(form *multipart.Writer, fieldName string, value inpu
 	return errCopy
 }
 
-func addFormFieldInputMediaSlice(form *multipart.Writer, fieldName string, value []inputMedia) error {
+func addFormFieldInputMediaSlice(form *multipart.Writer, fieldName string, value []inputMedia, progress fileProgressFunc) error {
 	var lines []string
 	for _, media := range value {
-		line, err := addFormFieldInputMediaItem(form, media)
+		line, err := addFormFieldInputMediaItem(form, media, progress)
 		if err != nil {
 			return err
 		}
@@ -55,7 +55,7 @@ func Test_buildRequestForm(t *testing.T) {
 	form := multipart.NewWriter(buf)
 	form.SetBoundary("XXX") //nolint
 
-	fieldsCount, errBuild := buildRequestForm(form, &params)
+	fieldsCount, errBuild := buildRequestForm(form, &params, nil)
 	if errBuild != nil {
 		t.Error(errBuild)
 		return
@@ -107,3 +107,41 @@ Content-Disposition: form-data; name="inline_query_result_interface"
 	assertEqualInt(t, fieldsCount, 6)
 	assertFormData(t, buf.String(), expect)
 }
+
+func Test_buildRequestForm_ChatID(t *testing.T) {
+	chatID := models.NewChatID(-100123)
+
+	params := &ForwardMessageParams{
+		ChatID:     models.NewChatUsername("channel"),
+		FromChatID: &chatID,
+		MessageID:  1,
+	}
+
+	buf := bytes.NewBuffer(nil)
+	form := multipart.NewWriter(buf)
+	form.SetBoundary("XXX") //nolint
+
+	_, errBuild := buildRequestForm(form, params, nil)
+	assertNoErr(t, errBuild)
+	assertNoErr(t, form.Close())
+
+	expect := `--XXX
+Content-Disposition: form-data; name="chat_id"
+
+@channel
+--XXX
+Content-Disposition: form-data; name="from_chat_id"
+
+-100123
+--XXX
+Content-Disposition: form-data; name="message_id"
+
+1
+--XXX--
+`
+	assertFormData(t, buf.String(), expect)
+
+	data, _, errJSON := buildRequestJSON(params)
+	assertNoErr(t, errJSON)
+	assertEqualString(t, string(data), `{"chat_id":"@channel","from_chat_id":-100123,"message_id":1}`)
+}
@@ -0,0 +1,145 @@
+package bot
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/go-telegram/bot/models"
+)
+
+// hasUploads reports whether params contain files which must be sent as multipart/form-data:
+// InputFileUpload values and InputMedia with attach:// attachments
+func hasUploads(params any) bool {
+	return len(uploadReaders(params)) > 0
+}
+
+// uploadReaders returns readers of all files to upload in params
+func uploadReaders(params any) []io.Reader {
+	v := reflect.ValueOf(params).Elem()
+
+	var readers []io.Reader
+
+	for i := 0; i < v.NumField(); i++ {
+		jsonTag := v.Type().Field(i).Tag.Get("json")
+		if jsonTag == "-" || jsonTag == "" {
+			continue
+		}
+		switch vv := v.Field(i).Interface().(type) {
+		case *models.InputFileUpload:
+			if vv != nil {
+				readers = append(readers, vv.Data)
+			}
+		case inputMedia:
+			if isAttachment(vv) {
+				readers = append(readers, vv.Attachment())
+			}
+		case []models.InputMedia:
+			for _, m := range vv {
+				if isAttachment(m) {
+					readers = append(readers, m.Attachment())
+				}
+			}
+		case []models.InputPaidMedia:
+			for _, m := range vv {
+				if isAttachment(m) {
+					readers = append(readers, m.Attachment())
+				}
+			}
+		}
+	}
+
+	return readers
+}
+
+func isAttachment(m inputMedia) bool {
+	return strings.HasPrefix(m.GetMedia(), "attach://")
+}
+
+// buildRequestJSON builds application/json body for request
+// fields are encoded in the same way as in buildRequestForm, but without form-data parts for files
+func buildRequestJSON(params any) ([]byte, int, error) {
+	v := reflect.ValueOf(params).Elem()
+
+	buf := bytes.NewBuffer(nil)
+	buf.WriteByte('{')
+
+	var fieldsCount int
+
+	for i := 0; i < v.NumField(); i++ {
+		jsonTag := v.Type().Field(i).Tag.Get("json")
+		if jsonTag == "-" || jsonTag == "" {
+			continue
+		}
+		fieldName := strings.Split(jsonTag, ",")[0]
+		omitempty := strings.Contains(jsonTag, ",omitempty")
+
+		if omitempty && v.Field(i).IsZero() {
+			continue
+		}
+
+		var data []byte
+		var err error
+
+		// check fields by interface
+		switch {
+		case v.Field(i).Type().Implements(customMarshalInterface):
+			data, err = v.Field(i).Interface().(customMarshal).MarshalCustom()
+		case v.Field(i).Type().Implements(inputMediaInterface):
+			data, err = v.Field(i).Interface().(inputMedia).MarshalInputMedia()
+		default:
+			// check fields by type
+			switch vv := v.Field(i).Interface().(type) {
+			case []models.InputMedia:
+				data, err = marshalJSONSlice(len(vv), func(idx int) ([]byte, error) { return vv[idx].MarshalInputMedia() })
+			case []models.InputPaidMedia:
+				data, err = marshalJSONSlice(len(vv), func(idx int) ([]byte, error) { return vv[idx].MarshalInputMedia() })
+			case []models.InlineQueryResult:
+				data, err = marshalJSONSlice(len(vv), func(idx int) ([]byte, error) { return vv[idx].MarshalCustom() })
+			default:
+				data, err = json.Marshal(vv)
+			}
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if fieldsCount > 0 {
+			buf.WriteByte(',')
+		}
+		name, _ := json.Marshal(fieldName)
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(data)
+
+		fieldsCount++
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), fieldsCount, nil
+}
+
+func marshalJSONSlice(n int, marshal func(idx int) ([]byte, error)) ([]byte, error) {
+	if n == 0 {
+		return []byte("[]"), nil
+	}
+
+	buf := bytes.NewBuffer(nil)
+	buf.WriteByte('[')
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		line, err := marshal(i)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(line)
+	}
+	buf.WriteByte(']')
+
+	return buf.Bytes(), nil
+}
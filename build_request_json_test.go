@@ -0,0 +1,200 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func Test_buildRequestJSON(t *testing.T) {
+	params := struct {
+		String                     string                     `json:"string"`
+		InputFileString            *models.InputFileString    `json:"input_file_string"`
+		InputMediaSlice            []models.InputMedia        `json:"input_media_slice"`
+		InlineQueryResultSlice     []models.InlineQueryResult `json:"inline_query_result_slice"`
+		DefaultInt                 int                        `json:"default_int"`
+		InputMediaInterface        models.InputMedia          `json:"input_media_interface"`
+		InlineQueryResultInterface models.InlineQueryResult   `json:"inline_query_result_interface"`
+		ReplyMarkup                models.ReplyMarkup         `json:"reply_markup"`
+		NoJSONTag1                 string
+		NoJSONTag2                 string `json:"-"`
+		OmitEmptyString            string `json:"omit_empty_string,omitempty"`
+	}{
+		String:          "foo",
+		InputFileString: &models.InputFileString{Data: "content input file string"},
+		InputMediaSlice: []models.InputMedia{
+			&models.InputMediaPhoto{Media: "foobar", Caption: "bar"},
+		},
+		InlineQueryResultSlice: []models.InlineQueryResult{
+			&models.InlineQueryResultArticle{Title: "foo", Description: "bar", InputMessageContent: &models.InputTextMessageContent{MessageText: "foo"}},
+		},
+		DefaultInt:                 42,
+		InputMediaInterface:        &models.InputMediaPhoto{Media: "foo", Caption: "bar", ParseMode: "baz"},
+		InlineQueryResultInterface: &models.InlineQueryResultArticle{Title: "foo", Description: "bar", InputMessageContent: &models.InputTextMessageContent{MessageText: "foo"}},
+		ReplyMarkup: models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{
+			{{Text: "foo", CallbackData: "bar"}},
+		}},
+		NoJSONTag1:      "foo",
+		NoJSONTag2:      "bar",
+		OmitEmptyString: "",
+	}
+
+	data, fieldsCount, errBuild := buildRequestJSON(&params)
+	if errBuild != nil {
+		t.Fatal(errBuild)
+	}
+
+	expect := `{"string":"foo",` +
+		`"input_file_string":"content input file string",` +
+		`"input_media_slice":[{"type":"photo","media":"foobar","caption":"bar"}],` +
+		`"inline_query_result_slice":[{"type":"article","id":"","title":"foo","input_message_content":{"message_text":"foo"},"description":"bar"}],` +
+		`"default_int":42,` +
+		`"input_media_interface":{"type":"photo","media":"foo","caption":"bar","parse_mode":"baz"},` +
+		`"inline_query_result_interface":{"type":"article","id":"","title":"foo","input_message_content":{"message_text":"foo"},"description":"bar"},` +
+		`"reply_markup":{"inline_keyboard":[[{"text":"foo","callback_data":"bar","copy_text":{"text":""}}]]}}`
+
+	assertEqualInt(t, fieldsCount, 8)
+	assertEqualString(t, string(data), expect)
+}
+
+func Test_hasUploads(t *testing.T) {
+	tests := []struct {
+		name   string
+		params any
+		expect bool
+	}{
+		{"no files", &SendMessageParams{ChatID: 1, Text: "foo"}, false},
+		{"input file string", &SendPhotoParams{ChatID: 1, Photo: &models.InputFileString{Data: "foo"}}, false},
+		{"input file upload", &SendPhotoParams{ChatID: 1, Photo: &models.InputFileUpload{Filename: "foo.png", Data: strings.NewReader("foo")}}, true},
+		{"media without attachment", &SendMediaGroupParams{ChatID: 1, Media: []models.InputMedia{&models.InputMediaPhoto{Media: "foo"}}}, false},
+		{"media with attachment", &SendMediaGroupParams{ChatID: 1, Media: []models.InputMedia{&models.InputMediaPhoto{Media: "attach://foo.png"}}}, true},
+		{"edit media with attachment", &EditMessageMediaParams{ChatID: 1, Media: &models.InputMediaPhoto{Media: "attach://foo.png"}}, true},
+		{"paid media with attachment", &SendPaidMediaParams{ChatID: 1, Media: []models.InputPaidMedia{&models.InputPaidMediaPhoto{Media: "attach://foo.png"}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if hasUploads(tt.params) != tt.expect {
+				t.Fatalf("expect %v", tt.expect)
+			}
+		})
+	}
+}
+
+type contentTypeClientMock struct {
+	contentType string
+}
+
+func (c *contentTypeClientMock) Do(req *http.Request) (*http.Response, error) {
+	c.contentType = req.Header.Get("Content-Type")
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(`{"ok":true,"result":{}}`)),
+	}, nil
+}
+
+func Test_rawRequest_encoding(t *testing.T) {
+	upload := func() *SendPhotoParams {
+		return &SendPhotoParams{ChatID: 1, Photo: &models.InputFileUpload{Filename: "foo.png", Data: strings.NewReader("foo")}}
+	}
+
+	tests := []struct {
+		name        string
+		encoding    RequestEncoding
+		params      any
+		contentType string
+		expectErr   bool
+	}{
+		{"auto without files", RequestEncodingAuto, &SendMessageParams{ChatID: 1, Text: "foo"}, "application/json", false},
+		{"auto with files", RequestEncodingAuto, upload(), "multipart/form-data", false},
+		{"multipart without files", RequestEncodingMultipart, &SendMessageParams{ChatID: 1, Text: "foo"}, "multipart/form-data", false},
+		{"json without files", RequestEncodingJSON, &SendMessageParams{ChatID: 1, Text: "foo"}, "application/json", false},
+		{"json with files", RequestEncodingJSON, upload(), "", true},
+		{"empty params", RequestEncodingAuto, &DeleteWebhookParams{}, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &contentTypeClientMock{}
+			b := &Bot{client: c, requestEncoding: tt.encoding}
+
+			err := b.rawRequest(context.Background(), "foo", tt.params, nil)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.HasPrefix(c.contentType, tt.contentType) || (tt.contentType == "" && c.contentType != "") {
+				t.Fatalf("unexpected content type %q, expect %q", c.contentType, tt.contentType)
+			}
+		})
+	}
+}
+
+func benchmarkRequestParams() *SendMessageParams {
+	return &SendMessageParams{
+		ChatID:    123,
+		Text:      "hello world",
+		ParseMode: models.ParseModeHTML,
+		Entities:  []models.MessageEntity{{Type: models.MessageEntityTypeBold, Offset: 0, Length: 5}},
+		ReplyParameters: &models.ReplyParameters{
+			MessageID: 42,
+		},
+		ReplyMarkup: models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{
+			{{Text: "foo", CallbackData: "foo"}, {Text: "bar", CallbackData: "bar"}},
+			{{Text: "baz", URL: "https://example.com"}},
+		}},
+	}
+}
+
+func BenchmarkBuildRequest_JSON(b *testing.B) {
+	params := benchmarkRequestParams()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := buildRequestJSON(params); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBuildRequest_Multipart(b *testing.B) {
+	params := benchmarkRequestParams()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf := bytes.NewBuffer(nil)
+		form := multipart.NewWriter(buf)
+		if _, err := buildRequestForm(form, params, nil); err != nil {
+			b.Fatal(err)
+		}
+		if err := form.Close(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRawRequest_JSON(b *testing.B) {
+	benchmarkRawRequest(b, RequestEncodingJSON)
+}
+
+func BenchmarkRawRequest_Multipart(b *testing.B) {
+	benchmarkRawRequest(b, RequestEncodingMultipart)
+}
+
+func benchmarkRawRequest(b *testing.B, encoding RequestEncoding) {
+	bot := &Bot{client: &contentTypeClientMock{}, requestEncoding: encoding}
+	params := benchmarkRequestParams()
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := bot.rawRequest(ctx, "sendMessage", params, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
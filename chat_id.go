@@ -0,0 +1,45 @@
+package bot
+
+import (
+	"reflect"
+
+	"github.com/go-telegram/bot/models"
+)
+
+// typedChatIDFields are typed fields of params and the fields of type any, which they fill in
+var typedChatIDFields = [][2]string{
+	{"Chat", "ChatID"},
+	{"FromChat", "FromChatID"},
+}
+
+// withTypedChatIDs returns params, which have ChatID and FromChatID fields set from typed Chat and FromChat fields.
+// Params are copied only if a typed field is set, so the caller params are not changed, otherwise params are returned as is
+func withTypedChatIDs(params any) any {
+	v := reflect.ValueOf(params)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return params
+	}
+
+	var copied reflect.Value
+
+	for _, names := range typedChatIDFields {
+		typed := v.Elem().FieldByName(names[0])
+		if !typed.IsValid() || typed.IsZero() {
+			continue
+		}
+		chatID, ok := typed.Interface().(models.ChatID)
+		if !ok {
+			continue
+		}
+		if !copied.IsValid() {
+			copied = reflect.New(v.Elem().Type())
+			copied.Elem().Set(v.Elem())
+		}
+		copied.Elem().FieldByName(names[1]).Set(reflect.ValueOf(chatID))
+	}
+
+	if !copied.IsValid() {
+		return params
+	}
+	return copied.Interface()
+}
@@ -0,0 +1,45 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestBot_TypedChatIDs(t *testing.T) {
+	ctx := context.Background()
+
+	client := &httpClient{t: t, resp: `{"message_id":2,"chat":{"id":1}}`, reqFields: map[string]string{
+		"chat_id":      "@channel",
+		"from_chat_id": "-100123",
+		"message_id":   "1",
+	}}
+	b, err := New("xxx", WithSkipGetMe(), WithHTTPClient(0, client), WithParamsValidation())
+	assertNoErr(t, err)
+
+	params := &ForwardMessageParams{
+		ChatID:    1,
+		Chat:      models.NewChatUsername("channel"),
+		FromChat:  models.NewChatID(-100123),
+		MessageID: 1,
+	}
+	_, err = b.ForwardMessage(ctx, params)
+	assertNoErr(t, err)
+	assertTrue(t, params.ChatID == 1 && params.FromChatID == nil)
+
+	_, err = b.SendMessage(ctx, &SendMessageParams{Chat: models.ChatID{}, Text: "text"})
+	assertTrue(t, errors.Is(err, ErrorValidation))
+	assertEqualString(t, err.Error(), "validation error: invalid params for method sendMessage, chat_id is required")
+}
+
+func TestWithTypedChatIDs_NoCopy(t *testing.T) {
+	params := &SendMessageParams{ChatID: 1, Text: "text"}
+	assertTrue(t, withTypedChatIDs(params) == any(params))
+
+	params = &SendMessageParams{Chat: models.NewChatID(1), Text: "text"}
+	copied, ok := withTypedChatIDs(params).(*SendMessageParams)
+	assertTrue(t, ok && copied != params)
+	assertTrue(t, copied.ChatID == models.NewChatID(1) && params.ChatID == nil)
+}
@@ -0,0 +1,270 @@
+package bot
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-telegram/bot/models"
+)
+
+// RetryPolicy defines retries of updates which handlers failed, see WithRetryPolicy
+type RetryPolicy struct {
+	// MaxAttempts is the max number of handler calls for an update, including the first one
+	MaxAttempts int
+	// Backoff is the delay before the second attempt, doubled for each next attempt
+	Backoff time.Duration
+	// MaxBackoff limits the delay between attempts, 0 means no limit
+	MaxBackoff time.Duration
+}
+
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.Backoff
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if p.MaxBackoff > 0 && d > p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+	return d
+}
+
+// DeadLetter is an update which handlers failed after all attempts
+type DeadLetter struct {
+	Update   *models.Update `json:"update"`
+	Error    string         `json:"error"`
+	Attempts int            `json:"attempts"`
+	FailedAt time.Time      `json:"failed_at"`
+}
+
+// DeadLetterSink stores dead letters, see WithDeadLetterSink
+type DeadLetterSink interface {
+	Put(ctx context.Context, letter DeadLetter) error
+}
+
+// FileDeadLetterSink stores dead letters in the JSONL file.
+// Take moves the letters to the processing file, which is removed by Ack after the replay,
+// so letters are not lost if the process crashes during the replay
+type FileDeadLetterSink struct {
+	mx   sync.Mutex
+	path string
+}
+
+// NewFileDeadLetterSink returns DeadLetterSink which appends dead letters to the JSONL file
+func NewFileDeadLetterSink(path string) *FileDeadLetterSink {
+	return &FileDeadLetterSink{path: path}
+}
+
+func (s *FileDeadLetterSink) Put(_ context.Context, letter DeadLetter) error {
+	data, errMarshal := json.Marshal(letter)
+	if errMarshal != nil {
+		return errMarshal
+	}
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	f, errOpen := os.OpenFile(s.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if errOpen != nil {
+		return errOpen
+	}
+
+	_, errWrite := f.Write(append(data, '\n'))
+	if errWrite == nil {
+		errWrite = f.Sync()
+	}
+	errClose := f.Close()
+	if errWrite != nil {
+		return errWrite
+	}
+	return errClose
+}
+
+// Take moves dead letters to the processing file and returns them. Call Ack after the letters are replayed.
+// If the previous Take is not acked, for example the process crashed during the replay,
+// the letters of the processing file are returned again and new letters stay in the file until the next Take
+func (s *FileDeadLetterSink) Take() ([]DeadLetter, error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	processingPath := s.processingPath()
+
+	if _, errStat := os.Stat(processingPath); os.IsNotExist(errStat) {
+		if err := os.Rename(s.path, processingPath); err != nil {
+			if os.IsNotExist(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+	}
+
+	data, errRead := os.ReadFile(processingPath)
+	if errRead != nil {
+		return nil, errRead
+	}
+
+	var letters []DeadLetter
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), len(data)+1)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		letter := DeadLetter{}
+		if err := json.Unmarshal(scanner.Bytes(), &letter); err != nil {
+			return nil, fmt.Errorf("error decode dead letter, %w", err)
+		}
+		letters = append(letters, letter)
+	}
+
+	return letters, nil
+}
+
+// Ack removes the processing file with letters returned by Take. Letters, which fail again in the replay,
+// are already stored in the sink file
+func (s *FileDeadLetterSink) Ack() error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if err := os.Remove(s.processingPath()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (s *FileDeadLetterSink) processingPath() string {
+	return s.path + ".processing"
+}
+
+// ReplayDeadLetters processes dead letters again with the bot handlers, one by one.
+// Deduplication is skipped. Updates which fail again are sent to the dead letter sink.
+// Returns the number of failed updates
+func (b *Bot) ReplayDeadLetters(ctx context.Context, letters []DeadLetter) int {
+	var failed int
+
+	for _, letter := range letters {
+		if letter.Update == nil {
+			continue
+		}
+		if err := b.handleUpdate(ctx, letter.Update); err != nil {
+			failed++
+		}
+	}
+
+	return failed
+}
+
+type handlerFailureKey struct{}
+
+type handlerFailure struct {
+	mx  sync.Mutex
+	err error
+}
+
+// FailUpdate marks the update processed by the current handler as failed.
+// Failed updates are retried according to WithRetryPolicy and sent to the sink from WithDeadLetterSink
+func FailUpdate(ctx context.Context, err error) {
+	f, ok := ctx.Value(handlerFailureKey{}).(*handlerFailure)
+	if !ok || err == nil {
+		return
+	}
+
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	f.err = err
+}
+
+// HandlerWithError converts handler which returns an error to HandlerFunc, see FailUpdate
+func HandlerWithError(h func(ctx context.Context, bot *Bot, update *models.Update) error) HandlerFunc {
+	return func(ctx context.Context, bot *Bot, update *models.Update) {
+		if err := h(ctx, bot, update); err != nil {
+			FailUpdate(ctx, err)
+		}
+	}
+}
+
+// handleUpdate runs the matched handler with middlewares, retries it and sends the update to the dead letter sink on failure
+func (b *Bot) handleUpdate(ctx context.Context, upd *models.Update) error {
+	h := b.findHandler(upd)
+
+	var r HandlerFunc
+	if b.tracer != nil {
+		r = b.applyTracedMiddlewares(h, b.middlewares...)
+	} else {
+		r = applyMiddlewares(h, b.middlewares...)
+	}
+
+	maxAttempts := b.retryPolicy.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	var err error
+	var attempt int
+
+	for attempt = 1; attempt <= maxAttempts; attempt++ {
+		err = b.runHandler(ctx, r, upd)
+		if err == nil {
+			return nil
+		}
+		if attempt == maxAttempts {
+			break
+		}
+
+		b.error("handler failed for update %d, attempt %d, %w", upd.ID, attempt, err)
+		b.metrics.handlerRetry()
+
+		select {
+		case <-ctx.Done():
+			err = fmt.Errorf("%w, retries stopped, %v", err, ctx.Err())
+		case <-time.After(b.retryPolicy.backoff(attempt)):
+			continue
+		}
+		break
+	}
+
+	b.error("handler failed for update %d after %d attempts, %w", upd.ID, attempt, err)
+
+	if b.deadLetterSink != nil {
+		letter := DeadLetter{
+			Update:   upd,
+			Error:    err.Error(),
+			Attempts: attempt,
+			FailedAt: time.Now(),
+		}
+		if errPut := b.deadLetterSink.Put(context.Background(), letter); errPut != nil {
+			b.error("error put update %d to dead letter sink, %w", upd.ID, errPut)
+		}
+	}
+
+	return err
+}
+
+// runHandler calls the handler and returns the error set by FailUpdate.
+// Handler panics are recovered only if retry policy or dead letter sink is set
+func (b *Bot) runHandler(ctx context.Context, h HandlerFunc, upd *models.Update) (err error) {
+	f := &handlerFailure{}
+	ctx = context.WithValue(ctx, handlerFailureKey{}, f)
+
+	if b.retryPolicy.MaxAttempts > 1 || b.deadLetterSink != nil {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("handler panic: %v", r)
+			}
+		}()
+	}
+
+	h(ctx, b, upd)
+
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	return f.err
+}
@@ -0,0 +1,143 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	p := RetryPolicy{Backoff: time.Second, MaxBackoff: 3 * time.Second}
+
+	assertTrue(t, p.backoff(1) == time.Second)
+	assertTrue(t, p.backoff(2) == 2*time.Second)
+	assertTrue(t, p.backoff(3) == 3*time.Second)
+}
+
+func TestProcessUpdate_Retry(t *testing.T) {
+	var calls int
+	b := &Bot{
+		defaultHandlerFunc: HandlerWithError(func(ctx context.Context, bot *Bot, update *models.Update) error {
+			calls++
+			if calls < 3 {
+				return errors.New("temporary error")
+			}
+			return nil
+		}),
+		notAsyncHandlers: true,
+		errorsHandler:    func(err error) {},
+		retryPolicy:      RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond},
+		deadLetterSink:   NewFileDeadLetterSink(filepath.Join(t.TempDir(), "dead.jsonl")),
+	}
+
+	b.ProcessUpdate(context.Background(), &models.Update{ID: 1})
+
+	assertEqualInt(t, calls, 3)
+
+	letters, err := b.deadLetterSink.(*FileDeadLetterSink).Take()
+	assertNoErr(t, err)
+	assertEqualInt(t, len(letters), 0)
+}
+
+func TestProcessUpdate_DeadLetter(t *testing.T) {
+	var calls int
+	var fail = true
+	b := &Bot{
+		defaultHandlerFunc: func(ctx context.Context, bot *Bot, update *models.Update) {
+			calls++
+			if fail {
+				panic("handler error")
+			}
+		},
+		notAsyncHandlers: true,
+		errorsHandler:    func(err error) {},
+		retryPolicy:      RetryPolicy{MaxAttempts: 2},
+	}
+
+	sink := NewFileDeadLetterSink(filepath.Join(t.TempDir(), "dead.jsonl"))
+	b.deadLetterSink = sink
+
+	ctx := context.Background()
+
+	b.ProcessUpdate(ctx, &models.Update{ID: 1})
+	b.ProcessUpdate(ctx, &models.Update{ID: 2})
+
+	assertEqualInt(t, calls, 4)
+
+	letters, err := sink.Take()
+	assertNoErr(t, err)
+	assertEqualInt(t, len(letters), 2)
+	assertEqualInt(t, int(letters[0].Update.ID), 1)
+	assertEqualInt(t, letters[0].Attempts, 2)
+	assertEqualString(t, letters[0].Error, "handler panic: handler error")
+
+	// failed again, stored back to the sink
+	assertEqualInt(t, b.ReplayDeadLetters(ctx, letters[:1]), 1)
+
+	fail = false
+	assertEqualInt(t, b.ReplayDeadLetters(ctx, letters[1:]), 0)
+	assertNoErr(t, sink.Ack())
+
+	letters, err = sink.Take()
+	assertNoErr(t, err)
+	assertEqualInt(t, len(letters), 1)
+	assertEqualInt(t, int(letters[0].Update.ID), 1)
+}
+
+func TestProcessUpdate_FailUpdateWithoutPolicy(t *testing.T) {
+	var errs []error
+	b := &Bot{
+		defaultHandlerFunc: func(ctx context.Context, bot *Bot, update *models.Update) {
+			FailUpdate(ctx, errors.New("failed"))
+		},
+		notAsyncHandlers: true,
+		errorsHandler: func(err error) {
+			errs = append(errs, err)
+		},
+	}
+
+	b.ProcessUpdate(context.Background(), &models.Update{ID: 1})
+
+	assertEqualInt(t, len(errs), 1)
+	assertEqualString(t, errs[0].Error(), "handler failed for update 1 after 1 attempts, failed")
+}
+
+func TestFileDeadLetterSink_TakeWithoutAck(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dead.jsonl")
+	sink := NewFileDeadLetterSink(path)
+	ctx := context.Background()
+
+	letters, err := sink.Take()
+	assertNoErr(t, err)
+	assertEqualInt(t, len(letters), 0)
+
+	assertNoErr(t, sink.Put(ctx, DeadLetter{Update: &models.Update{ID: 1}}))
+
+	letters, err = sink.Take()
+	assertNoErr(t, err)
+	assertEqualInt(t, len(letters), 1)
+
+	// the process crashes during the replay, a new letter is stored meanwhile
+	assertNoErr(t, sink.Put(ctx, DeadLetter{Update: &models.Update{ID: 2}}))
+	sink = NewFileDeadLetterSink(path)
+
+	letters, err = sink.Take()
+	assertNoErr(t, err)
+	assertEqualInt(t, len(letters), 1)
+	assertEqualInt(t, int(letters[0].Update.ID), 1)
+	assertNoErr(t, sink.Ack())
+
+	letters, err = sink.Take()
+	assertNoErr(t, err)
+	assertEqualInt(t, len(letters), 1)
+	assertEqualInt(t, int(letters[0].Update.ID), 2)
+	assertNoErr(t, sink.Ack())
+
+	letters, err = sink.Take()
+	assertNoErr(t, err)
+	assertEqualInt(t, len(letters), 0)
+}
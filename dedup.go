@@ -0,0 +1,89 @@
+package bot
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/go-telegram/bot/models"
+)
+
+// DedupStore remembers ids of processed updates
+type DedupStore interface {
+	// Seen marks the update id as seen and reports whether it was seen before
+	Seen(ctx context.Context, updateID int64) (bool, error)
+}
+
+// MemoryDedupStore is an in-memory DedupStore, which remembers the last size update ids
+type MemoryDedupStore struct {
+	mx   sync.Mutex
+	ids  map[int64]struct{}
+	ring []int64
+	pos  int
+}
+
+// NewMemoryDedupStore returns new in-memory DedupStore with the window of size update ids
+func NewMemoryDedupStore(size int) *MemoryDedupStore {
+	if size < 1 {
+		size = 1
+	}
+
+	return &MemoryDedupStore{
+		ids:  make(map[int64]struct{}, size),
+		ring: make([]int64, 0, size),
+	}
+}
+
+func (s *MemoryDedupStore) Seen(_ context.Context, updateID int64) (bool, error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if _, ok := s.ids[updateID]; ok {
+		return true, nil
+	}
+
+	if len(s.ring) < cap(s.ring) {
+		s.ring = append(s.ring, updateID)
+	} else {
+		delete(s.ids, s.ring[s.pos])
+		s.ring[s.pos] = updateID
+		s.pos = (s.pos + 1) % len(s.ring)
+	}
+	s.ids[updateID] = struct{}{}
+
+	return false, nil
+}
+
+// DuplicateUpdates returns the number of updates dropped as duplicates, see WithDedup
+func (b *Bot) DuplicateUpdates() int64 {
+	return atomic.LoadInt64(&b.duplicateUpdates)
+}
+
+// isDuplicate reports whether the update was already processed. Updates without id are never duplicates.
+// The store marks the id as seen before handlers run, so updates delivered again from the disk queue after a crash
+// are seen, but not processed, and they are not duplicates
+func (b *Bot) isDuplicate(ctx context.Context, upd *models.Update) bool {
+	updateID := upd.ID
+	if b.dedupStore == nil || updateID == 0 {
+		return false
+	}
+
+	seen, err := b.dedupStore.Seen(ctx, updateID)
+	if err != nil {
+		b.error("error check update %d for duplicate, %w", updateID, err)
+		return false
+	}
+
+	if seen && b.diskQueue != nil && b.diskQueue.isRecovered(upd) {
+		return false
+	}
+
+	if seen {
+		atomic.AddInt64(&b.duplicateUpdates, 1)
+		if b.isDebug {
+			b.debugHandler("duplicate update %d dropped", updateID)
+		}
+	}
+
+	return seen
+}
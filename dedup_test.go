@@ -0,0 +1,86 @@
+package bot
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestMemoryDedupStore(t *testing.T) {
+	s := NewMemoryDedupStore(2)
+	ctx := context.Background()
+
+	seen := func(id int64) bool {
+		res, err := s.Seen(ctx, id)
+		assertNoErr(t, err)
+		return res
+	}
+
+	assertTrue(t, !seen(1))
+	assertTrue(t, seen(1))
+	assertTrue(t, !seen(2))
+	assertTrue(t, !seen(3)) // 1 is out of the window
+	assertTrue(t, !seen(1))
+	assertTrue(t, seen(3))
+}
+
+func TestProcessUpdate_Dedup(t *testing.T) {
+	var calls int
+	b := &Bot{
+		defaultHandlerFunc: func(ctx context.Context, bot *Bot, update *models.Update) {
+			calls++
+		},
+		notAsyncHandlers: true,
+		dedupStore:       NewMemoryDedupStore(10),
+	}
+
+	ctx := context.Background()
+
+	b.ProcessUpdate(ctx, &models.Update{ID: 1})
+	b.ProcessUpdate(ctx, &models.Update{ID: 1})
+	b.ProcessUpdate(ctx, &models.Update{ID: 2})
+	b.ProcessUpdate(ctx, &models.Update{})
+	b.ProcessUpdate(ctx, &models.Update{})
+
+	assertEqualInt(t, calls, 4)
+	assertEqualInt(t, int(b.DuplicateUpdates()), 1)
+}
+
+func TestProcessUpdate_DedupDiskQueueRecovery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "updates.wal")
+	store := NewMemoryDedupStore(10)
+	ctx := context.Background()
+
+	q, err := OpenDiskQueue(path)
+	assertNoErr(t, err)
+	assertNoErr(t, q.add(&models.Update{ID: 1}))
+	// the update is marked as seen, then the process crashes in the handler
+	_, err = store.Seen(ctx, 1)
+	assertNoErr(t, err)
+	assertNoErr(t, q.Close())
+
+	q, err = OpenDiskQueue(path)
+	assertNoErr(t, err)
+	defer q.Close()
+
+	var calls int
+	b := &Bot{
+		defaultHandlerFunc: func(ctx context.Context, bot *Bot, update *models.Update) {
+			calls++
+		},
+		notAsyncHandlers: true,
+		dedupStore:       store,
+		diskQueue:        q,
+	}
+
+	upd, ok := q.next(ctx)
+	assertTrue(t, ok)
+	b.ProcessUpdate(ctx, upd)
+	b.ProcessUpdate(ctx, &models.Update{ID: 1})
+
+	assertEqualInt(t, calls, 1)
+	assertEqualInt(t, int(b.DuplicateUpdates()), 1)
+	assertEqualInt(t, q.Len(), 0)
+}
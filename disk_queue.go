@@ -0,0 +1,389 @@
+package bot
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/go-telegram/bot/models"
+)
+
+// diskQueueMinCompactRecords is the minimal number of records in the queue file to start compaction
+const diskQueueMinCompactRecords = 1024
+
+const (
+	diskQueueOpAdd    = "add"
+	diskQueueOpAck    = "ack"
+	diskQueueOpOffset = "offset"
+)
+
+type diskQueueRecord struct {
+	Op       string         `json:"op"`
+	Seq      int64          `json:"seq,omitempty"`
+	UpdateID int64          `json:"update_id,omitempty"`
+	Update   *models.Update `json:"update,omitempty"`
+}
+
+// DiskQueue is a write-ahead queue of updates on local disk, see WithDiskQueue.
+// Updates are written to the file before they are acknowledged to Telegram
+// and removed after handling, so updates are not lost if the process crashes
+type DiskQueue struct {
+	mx     sync.Mutex
+	path   string
+	file   *os.File
+	notify chan struct{}
+
+	seq          int64
+	lastUpdateID int64
+	records      int
+
+	pending   map[int64]*models.Update // by seq
+	seqs      map[*models.Update]int64
+	updateIDs map[int64]struct{}
+	queue     []int64
+	// recovered are updates loaded from the queue file, they were written before the previous close or crash
+	recovered map[*models.Update]struct{}
+}
+
+// OpenDiskQueue opens or creates the queue file. Updates not handled before the previous close or crash are delivered again
+func OpenDiskQueue(path string) (*DiskQueue, error) {
+	q := &DiskQueue{
+		path:      path,
+		notify:    make(chan struct{}, 1),
+		pending:   map[int64]*models.Update{},
+		seqs:      map[*models.Update]int64{},
+		updateIDs: map[int64]struct{}{},
+		recovered: map[*models.Update]struct{}{},
+	}
+
+	if err := q.load(); err != nil {
+		return nil, err
+	}
+
+	q.mx.Lock()
+	defer q.mx.Unlock()
+
+	if err := q.compact(); err != nil {
+		return nil, err
+	}
+
+	return q, nil
+}
+
+// load reads records of the queue file. An incomplete last record, written on crash, is ignored.
+// A corrupt record before the last one is an error, so the queue file is not compacted without the records after it
+func (q *DiskQueue) load() error {
+	data, errRead := os.ReadFile(q.path)
+	if errRead != nil && !os.IsNotExist(errRead) {
+		return fmt.Errorf("error read disk queue %s, %w", q.path, errRead)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), len(data)+1)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		r := diskQueueRecord{}
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			if scanner.Scan() {
+				return fmt.Errorf("error parse disk queue %s, corrupt record at line %d, %w", q.path, line, err)
+			}
+			break
+		}
+
+		switch r.Op {
+		case diskQueueOpAdd:
+			if r.Update == nil {
+				continue
+			}
+			q.pending[r.Seq] = r.Update
+			if r.Update.ID > q.lastUpdateID {
+				q.lastUpdateID = r.Update.ID
+			}
+		case diskQueueOpAck:
+			delete(q.pending, r.Seq)
+		case diskQueueOpOffset:
+			if r.UpdateID > q.lastUpdateID {
+				q.lastUpdateID = r.UpdateID
+			}
+		}
+		if r.Seq > q.seq {
+			q.seq = r.Seq
+		}
+	}
+
+	for seq, upd := range q.pending {
+		q.seqs[upd] = seq
+		q.updateIDs[upd.ID] = struct{}{}
+		q.recovered[upd] = struct{}{}
+		q.queue = append(q.queue, seq)
+	}
+	sort.Slice(q.queue, func(i, j int) bool { return q.queue[i] < q.queue[j] })
+
+	return nil
+}
+
+// compact rewrites the queue file with pending updates only
+func (q *DiskQueue) compact() error {
+	tmpPath := q.path + ".tmp"
+
+	tmp, errCreate := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if errCreate != nil {
+		return fmt.Errorf("error create disk queue file %s, %w", tmpPath, errCreate)
+	}
+
+	w := bufio.NewWriter(tmp)
+	enc := json.NewEncoder(w)
+
+	errWrite := enc.Encode(diskQueueRecord{Op: diskQueueOpOffset, Seq: q.seq, UpdateID: q.lastUpdateID})
+	seqs := make([]int64, 0, len(q.pending))
+	for seq := range q.pending {
+		seqs = append(seqs, seq)
+	}
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+	for _, seq := range seqs {
+		if errWrite != nil {
+			break
+		}
+		errWrite = enc.Encode(diskQueueRecord{Op: diskQueueOpAdd, Seq: seq, Update: q.pending[seq]})
+	}
+	if errWrite == nil {
+		errWrite = w.Flush()
+	}
+	if errWrite == nil {
+		errWrite = tmp.Sync()
+	}
+	errClose := tmp.Close()
+	if errWrite == nil {
+		errWrite = errClose
+	}
+	if errWrite != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("error write disk queue file %s, %w", tmpPath, errWrite)
+	}
+
+	if q.file != nil {
+		_ = q.file.Close()
+		q.file = nil
+	}
+
+	if err := os.Rename(tmpPath, q.path); err != nil {
+		return fmt.Errorf("error rename disk queue file %s, %w", tmpPath, err)
+	}
+
+	file, errOpen := os.OpenFile(q.path, os.O_WRONLY|os.O_APPEND, 0o600)
+	if errOpen != nil {
+		return fmt.Errorf("error open disk queue file %s, %w", q.path, errOpen)
+	}
+
+	q.file = file
+	q.records = len(seqs) + 1
+
+	return nil
+}
+
+func (q *DiskQueue) write(r diskQueueRecord, sync bool) error {
+	data, errMarshal := json.Marshal(r)
+	if errMarshal != nil {
+		return errMarshal
+	}
+
+	if _, err := q.file.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	q.records++
+
+	if sync {
+		return q.file.Sync()
+	}
+	return nil
+}
+
+// add writes the update to the disk and returns after fsync. Updates with id already in the queue are skipped
+func (q *DiskQueue) add(upd *models.Update) error {
+	_, err := q.insert(upd, true)
+	return err
+}
+
+// claim writes the update to the disk like add, but does not dispatch it to the updates channel,
+// the caller handles the update and acks it. After restart, not acked updates are dispatched as usual.
+// Returns false if the update with the same id is already in the queue
+func (q *DiskQueue) claim(upd *models.Update) (bool, error) {
+	return q.insert(upd, false)
+}
+
+func (q *DiskQueue) insert(upd *models.Update, dispatch bool) (bool, error) {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+
+	if q.file == nil {
+		return false, fmt.Errorf("disk queue is closed")
+	}
+
+	if _, ok := q.updateIDs[upd.ID]; ok && upd.ID != 0 {
+		return false, nil
+	}
+
+	seq := q.seq + 1
+	if err := q.write(diskQueueRecord{Op: diskQueueOpAdd, Seq: seq, Update: upd}, true); err != nil {
+		return false, fmt.Errorf("error write disk queue file %s, %w", q.path, err)
+	}
+
+	q.seq = seq
+	q.pending[seq] = upd
+	q.seqs[upd] = seq
+	q.updateIDs[upd.ID] = struct{}{}
+	if upd.ID > q.lastUpdateID {
+		q.lastUpdateID = upd.ID
+	}
+
+	if !dispatch {
+		return true, nil
+	}
+
+	q.queue = append(q.queue, seq)
+
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+
+	return true, nil
+}
+
+// ack removes the handled update from the queue. Unknown updates are ignored
+func (q *DiskQueue) ack(upd *models.Update) error {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+
+	seq, ok := q.seqs[upd]
+	if !ok || q.file == nil {
+		return nil
+	}
+
+	if err := q.write(diskQueueRecord{Op: diskQueueOpAck, Seq: seq}, false); err != nil {
+		return fmt.Errorf("error write disk queue file %s, %w", q.path, err)
+	}
+
+	delete(q.pending, seq)
+	delete(q.seqs, upd)
+	delete(q.updateIDs, upd.ID)
+	delete(q.recovered, upd)
+
+	if q.records >= diskQueueMinCompactRecords && q.records > 2*len(q.pending) {
+		return q.compact()
+	}
+
+	return nil
+}
+
+// next returns the next not dispatched update, blocks until it is available or ctx is done
+func (q *DiskQueue) next(ctx context.Context) (*models.Update, bool) {
+	for {
+		q.mx.Lock()
+		for len(q.queue) > 0 {
+			seq := q.queue[0]
+			q.queue = q.queue[1:]
+			if upd, ok := q.pending[seq]; ok {
+				q.mx.Unlock()
+				return upd, true
+			}
+		}
+		q.mx.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, false
+		case <-q.notify:
+		}
+	}
+}
+
+// isRecovered reports whether the update was loaded from the queue file, not added after open
+func (q *DiskQueue) isRecovered(upd *models.Update) bool {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+
+	_, ok := q.recovered[upd]
+	return ok
+}
+
+// requeue returns the update, which was not dispatched after next, to the front of the queue
+func (q *DiskQueue) requeue(upd *models.Update) {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+
+	seq, ok := q.seqs[upd]
+	if !ok {
+		return
+	}
+
+	q.queue = append([]int64{seq}, q.queue...)
+}
+
+// LastUpdateID returns the max id of updates written to the queue
+func (q *DiskQueue) LastUpdateID() int64 {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+
+	return q.lastUpdateID
+}
+
+// Len returns the number of not handled updates
+func (q *DiskQueue) Len() int {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+
+	return len(q.pending)
+}
+
+// Close closes the queue file. Call it after the bot is stopped
+func (q *DiskQueue) Close() error {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+
+	if q.file == nil {
+		return nil
+	}
+
+	err := q.file.Close()
+	q.file = nil
+	return err
+}
+
+// feedDiskQueue sends updates from the disk queue to the updates channel
+func (b *Bot) feedDiskQueue(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	for {
+		upd, ok := b.diskQueue.next(ctx)
+		if !ok {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			// the update is dispatched again, if the bot is started again with the same queue
+			b.diskQueue.requeue(upd)
+			return
+		case b.updates <- upd:
+		}
+	}
+}
+
+// ackUpdate removes the handled update from the disk queue
+func (b *Bot) ackUpdate(upd *models.Update) {
+	if b.diskQueue == nil {
+		return
+	}
+
+	if err := b.diskQueue.ack(upd); err != nil {
+		b.error("error ack update %d in disk queue, %w", upd.ID, err)
+	}
+}
@@ -0,0 +1,218 @@
+package bot
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestDiskQueue_Recovery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "updates.wal")
+
+	q, err := OpenDiskQueue(path)
+	assertNoErr(t, err)
+
+	upd1 := &models.Update{ID: 1}
+	upd2 := &models.Update{ID: 2}
+	assertNoErr(t, q.add(upd1))
+	assertNoErr(t, q.add(upd2))
+	assertNoErr(t, q.add(&models.Update{ID: 2})) // duplicate
+	assertEqualInt(t, q.Len(), 2)
+
+	assertNoErr(t, q.ack(upd1))
+	assertNoErr(t, q.Close())
+
+	// simulate crash during write
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	assertNoErr(t, err)
+	_, err = f.WriteString(`{"op":"add","seq":4,"upd`)
+	assertNoErr(t, err)
+	assertNoErr(t, f.Close())
+
+	q, err = OpenDiskQueue(path)
+	assertNoErr(t, err)
+	defer q.Close()
+
+	assertEqualInt(t, q.Len(), 1)
+	assertEqualInt(t, int(q.LastUpdateID()), 2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	upd, ok := q.next(ctx)
+	assertTrue(t, ok)
+	assertEqualInt(t, int(upd.ID), 2)
+
+	assertNoErr(t, q.ack(upd))
+	assertEqualInt(t, q.Len(), 0)
+
+	ctxEmpty, cancelEmpty := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancelEmpty()
+
+	_, ok = q.next(ctxEmpty)
+	assertTrue(t, !ok)
+}
+
+func TestDiskQueue_CorruptRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "updates.wal")
+
+	q, err := OpenDiskQueue(path)
+	assertNoErr(t, err)
+	assertNoErr(t, q.add(&models.Update{ID: 1}))
+	assertNoErr(t, q.Close())
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	assertNoErr(t, err)
+	_, err = f.WriteString("{\"op\":\"add\",\"seq\":2,\"upd\n{\"op\":\"add\",\"seq\":3,\"update\":{\"update_id\":3}}\n")
+	assertNoErr(t, err)
+	assertNoErr(t, f.Close())
+
+	before, err := os.ReadFile(path)
+	assertNoErr(t, err)
+
+	_, err = OpenDiskQueue(path)
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+
+	after, err := os.ReadFile(path)
+	assertNoErr(t, err)
+	assertEqualString(t, string(after), string(before))
+}
+
+func TestDiskQueue_Requeue(t *testing.T) {
+	q, err := OpenDiskQueue(filepath.Join(t.TempDir(), "updates.wal"))
+	assertNoErr(t, err)
+	defer q.Close()
+
+	assertNoErr(t, q.add(&models.Update{ID: 1}))
+	assertNoErr(t, q.add(&models.Update{ID: 2}))
+
+	b := &Bot{diskQueue: q, updates: make(chan *models.Update)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	go b.feedDiskQueue(ctx, wg)
+
+	// nobody reads the updates channel, the first update is taken from the queue and not sent
+	time.Sleep(time.Millisecond * 50)
+	cancel()
+	wg.Wait()
+
+	ctxNext, cancelNext := context.WithTimeout(context.Background(), time.Second)
+	defer cancelNext()
+
+	upd, ok := q.next(ctxNext)
+	assertTrue(t, ok)
+	assertEqualInt(t, int(upd.ID), 1)
+}
+
+func TestDiskQueue_Compaction(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "updates.wal")
+
+	q, err := OpenDiskQueue(path)
+	assertNoErr(t, err)
+	defer q.Close()
+
+	for i := 1; i <= diskQueueMinCompactRecords; i++ {
+		upd := &models.Update{ID: int64(i)}
+		assertNoErr(t, q.add(upd))
+		assertNoErr(t, q.ack(upd))
+	}
+
+	if q.records >= diskQueueMinCompactRecords {
+		t.Fatalf("expected compaction, records %d", q.records)
+	}
+
+	data, err := os.ReadFile(path)
+	assertNoErr(t, err)
+	if len(data) > 1024 {
+		t.Fatalf("unexpected queue file size %d", len(data))
+	}
+	assertEqualInt(t, int(q.LastUpdateID()), diskQueueMinCompactRecords)
+}
+
+func TestBot_DiskQueue_Webhook(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "updates.wal")
+
+	q, err := OpenDiskQueue(path)
+	assertNoErr(t, err)
+	defer q.Close()
+
+	processed := make(chan int64, 1)
+	b := &Bot{
+		updates:   make(chan *models.Update, 1),
+		diskQueue: q,
+		workers:   1,
+		defaultHandlerFunc: func(_ context.Context, _ *Bot, update *models.Update) {
+			processed <- update.ID
+		},
+		notAsyncHandlers: true,
+	}
+
+	assertTrue(t, b.enqueueUpdate(&models.Update{ID: 42}))
+	assertEqualInt(t, q.Len(), 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go b.StartWebhook(ctx)
+
+	select {
+	case id := <-processed:
+		assertEqualInt(t, int(id), 42)
+	case <-time.After(time.Second):
+		t.Fatal("update is not processed")
+	}
+
+	for i := 0; i < 100 && q.Len() > 0; i++ {
+		time.Sleep(time.Millisecond * 10)
+	}
+	assertEqualInt(t, q.Len(), 0)
+}
+
+func TestBot_DiskQueue_SyncWebhook(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "updates.wal")
+
+	q, err := OpenDiskQueue(path)
+	assertNoErr(t, err)
+
+	var queued int
+	b := &Bot{
+		diskQueue:   q,
+		syncWebhook: true,
+		defaultHandlerFunc: func(_ context.Context, _ *Bot, update *models.Update) {
+			queued = q.Len()
+			if update.ID == 2 {
+				// the process crashes before the update is acked
+				_ = q.Close()
+			}
+		},
+	}
+
+	w := httptest.NewRecorder()
+	b.WebhookHandler()(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"update_id":1}`)))
+	assertEqualInt(t, w.Code, http.StatusOK)
+	assertEqualInt(t, queued, 1)
+	assertEqualInt(t, q.Len(), 0)
+
+	b.WebhookHandler()(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"update_id":2}`)))
+
+	q, err = OpenDiskQueue(path)
+	assertNoErr(t, err)
+	defer q.Close()
+	assertEqualInt(t, q.Len(), 1)
+
+	upd, ok := q.next(context.Background())
+	assertTrue(t, ok)
+	assertEqualInt(t, int(upd.ID), 2)
+}
@@ -0,0 +1,333 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/go-telegram/bot/models"
+)
+
+// downloadResumeOverlap is the number of already downloaded bytes which are downloaded again
+// and compared with the local content before resuming a download
+const downloadResumeOverlap = 64 << 10
+
+var errResumeMismatch = errors.New("downloaded content does not match local file")
+
+// DownloadFileParams describes a file to download. Either FileID or File must be set
+type DownloadFileParams struct {
+	// FileID is used to call GetFile method if File is nil
+	FileID string
+	// File is a result of GetFile method
+	File *models.File
+	// MaxSize limits the file size in bytes, 0 means no limit
+	MaxSize int64
+	// SHA256 is an optional checksum of the file content, verified after download
+	SHA256 []byte
+}
+
+// DownloadFile calls GetFile if needed and writes the file content to w.
+// If the checksum does not match, ErrorChecksumMismatch is returned after the content is written
+func (b *Bot) DownloadFile(ctx context.Context, params *DownloadFileParams, w io.Writer) (*models.File, error) {
+	ctx, cancel := withTimeout(ctx, b.downloadTimeout)
+	defer cancel()
+
+	f, errFile := b.prepareDownload(ctx, params)
+	if errFile != nil {
+		return nil, errFile
+	}
+
+	body, _, errOpen := b.openFileDownload(ctx, f, 0)
+	if errOpen != nil {
+		return f, errOpen
+	}
+	defer func() { _ = body.Close() }()
+
+	var h hash.Hash
+	if params.SHA256 != nil {
+		h = sha256.New()
+		w = io.MultiWriter(w, h)
+	}
+
+	if _, errCopy := copyFileContent(w, body, params.MaxSize, 0); errCopy != nil {
+		return f, errCopy
+	}
+
+	if h != nil && !bytes.Equal(h.Sum(nil), params.SHA256) {
+		return f, ErrorChecksumMismatch
+	}
+
+	return f, nil
+}
+
+// DownloadFileToPath downloads the file to the path.
+// If the path already contains a part of the file, the download is resumed.
+// Before resuming, the tail of the local content is downloaded again and compared,
+// and if it differs, the file is downloaded from the beginning
+func (b *Bot) DownloadFileToPath(ctx context.Context, params *DownloadFileParams, path string) (*models.File, error) {
+	ctx, cancel := withTimeout(ctx, b.downloadTimeout)
+	defer cancel()
+
+	f, errFile := b.prepareDownload(ctx, params)
+	if errFile != nil {
+		return nil, errFile
+	}
+
+	file, errOpen := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if errOpen != nil {
+		return f, fmt.Errorf("error open file %s, %w", path, errOpen)
+	}
+
+	errDownload := b.downloadToFile(ctx, f, params, file, true)
+	if errors.Is(errDownload, errResumeMismatch) {
+		errDownload = b.downloadToFile(ctx, f, params, file, false)
+	}
+
+	errClose := file.Close()
+	if errDownload != nil {
+		return f, errDownload
+	}
+	if errClose != nil {
+		return f, fmt.Errorf("error close file %s, %w", path, errClose)
+	}
+
+	return f, nil
+}
+
+// DownloadFileToTemp downloads the file to a new temporary file and returns its path.
+// dir and pattern have the same meaning as in os.CreateTemp. The temporary file is removed on error
+func (b *Bot) DownloadFileToTemp(ctx context.Context, params *DownloadFileParams, dir, pattern string) (string, error) {
+	ctx, cancel := withTimeout(ctx, b.downloadTimeout)
+	defer cancel()
+
+	f, errFile := b.prepareDownload(ctx, params)
+	if errFile != nil {
+		return "", errFile
+	}
+
+	file, errCreate := os.CreateTemp(dir, pattern)
+	if errCreate != nil {
+		return "", fmt.Errorf("error create temp file, %w", errCreate)
+	}
+
+	errDownload := b.downloadToFile(ctx, f, params, file, false)
+	errClose := file.Close()
+	if errDownload == nil && errClose != nil {
+		errDownload = fmt.Errorf("error close file %s, %w", file.Name(), errClose)
+	}
+	if errDownload != nil {
+		_ = os.Remove(file.Name())
+		return "", errDownload
+	}
+
+	return file.Name(), nil
+}
+
+func (b *Bot) prepareDownload(ctx context.Context, params *DownloadFileParams) (*models.File, error) {
+	f := params.File
+	if f == nil {
+		if params.FileID == "" {
+			return nil, fmt.Errorf("error download file, file id is empty")
+		}
+		var errGetFile error
+		f, errGetFile = b.GetFile(ctx, &GetFileParams{FileID: params.FileID})
+		if errGetFile != nil {
+			return nil, fmt.Errorf("error get file, %w", errGetFile)
+		}
+	}
+
+	if f.FilePath == "" {
+		return nil, fmt.Errorf("error download file %s, file path is empty", f.FileID)
+	}
+
+	if b.maxDownloadSize > 0 && f.FileSize > b.maxDownloadSize {
+		return nil, fmt.Errorf("%w, file size %d, max download size %d", ErrorFileTooLarge, f.FileSize, b.maxDownloadSize)
+	}
+
+	if params.MaxSize > 0 && f.FileSize > params.MaxSize {
+		return nil, fmt.Errorf("%w, file size %d, max size %d", ErrorFileTooLarge, f.FileSize, params.MaxSize)
+	}
+
+	return f, nil
+}
+
+// downloadToFile writes the file content to the local file, resuming from the local file size if resume is true
+func (b *Bot) downloadToFile(ctx context.Context, f *models.File, params *DownloadFileParams, file *os.File, resume bool) error {
+	var size int64
+	if resume {
+		info, errStat := file.Stat()
+		if errStat != nil {
+			return fmt.Errorf("error stat file %s, %w", file.Name(), errStat)
+		}
+		size = info.Size()
+		if f.FileSize > 0 && size > f.FileSize {
+			size = 0
+		}
+	}
+
+	offset := size - downloadResumeOverlap
+	if offset < 0 {
+		offset = 0
+	}
+
+	var h hash.Hash
+	if params.SHA256 != nil {
+		h = sha256.New()
+		if _, errHash := io.Copy(h, io.NewSectionReader(file, 0, offset)); errHash != nil {
+			return fmt.Errorf("error read file %s, %w", file.Name(), errHash)
+		}
+	}
+
+	body, partial, errOpen := b.openFileDownload(ctx, f, offset)
+	if errOpen != nil {
+		return errOpen
+	}
+	defer func() { _ = body.Close() }()
+
+	if !partial {
+		// the server sent the whole file
+		size = 0
+		if h != nil {
+			h.Reset()
+		}
+	}
+
+	if overlap := size - offset; overlap > 0 {
+		remote := make([]byte, overlap)
+		if _, errRead := io.ReadFull(body, remote); errRead != nil {
+			if errors.Is(errRead, io.ErrUnexpectedEOF) || errors.Is(errRead, io.EOF) {
+				return errResumeMismatch
+			}
+			return fmt.Errorf("error read file content, %w", errRead)
+		}
+		local := make([]byte, overlap)
+		if _, errRead := file.ReadAt(local, offset); errRead != nil {
+			return fmt.Errorf("error read file %s, %w", file.Name(), errRead)
+		}
+		if !bytes.Equal(remote, local) {
+			return errResumeMismatch
+		}
+		if h != nil {
+			h.Write(remote)
+		}
+	}
+
+	if errTruncate := file.Truncate(size); errTruncate != nil {
+		return fmt.Errorf("error truncate file %s, %w", file.Name(), errTruncate)
+	}
+	if _, errSeek := file.Seek(size, io.SeekStart); errSeek != nil {
+		return fmt.Errorf("error seek file %s, %w", file.Name(), errSeek)
+	}
+
+	var w io.Writer = file
+	if h != nil {
+		w = io.MultiWriter(file, h)
+	}
+
+	if _, errCopy := copyFileContent(w, body, params.MaxSize, size); errCopy != nil {
+		return errCopy
+	}
+
+	if h != nil && !bytes.Equal(h.Sum(nil), params.SHA256) {
+		// do not resume from the corrupted content next time
+		_ = file.Truncate(0)
+		return ErrorChecksumMismatch
+	}
+
+	return nil
+}
+
+// openFileDownload requests the file content starting from offset.
+// partial reports whether the response contains the content from offset or the whole file
+func (b *Bot) openFileDownload(ctx context.Context, f *models.File, offset int64) (body io.ReadCloser, partial bool, err error) {
+	if b.localMode && filepath.IsAbs(f.FilePath) {
+		return openLocalFile(ctx, f, offset)
+	}
+
+	req, errRequest := http.NewRequestWithContext(ctx, http.MethodGet, b.FileDownloadLink(f), http.NoBody)
+	if errRequest != nil {
+		return nil, false, fmt.Errorf("error create request for file %s, %w", f.FileID, errRequest)
+	}
+
+	if offset > 0 {
+		req.Header.Set("Range", "bytes="+strconv.FormatInt(offset, 10)+"-")
+	}
+
+	resp, errDo := b.fileDownloadClient().Do(req)
+	if errDo != nil {
+		return nil, false, fmt.Errorf("error do request for file %s, %w", f.FileID, b.redactURLError(errDo))
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, false, nil
+	case http.StatusPartialContent:
+		return resp.Body, true, nil
+	case http.StatusRequestedRangeNotSatisfiable:
+		_ = resp.Body.Close()
+		return nil, false, errResumeMismatch
+	default:
+		_ = resp.Body.Close()
+		return nil, false, fmt.Errorf("error download file %s, unexpected status code %d", f.FileID, resp.StatusCode)
+	}
+}
+
+// openLocalFile opens the file stored by the local Bot API server, reading stops when ctx is done
+func openLocalFile(ctx context.Context, f *models.File, offset int64) (io.ReadCloser, bool, error) {
+	if errCtx := ctx.Err(); errCtx != nil {
+		return nil, false, fmt.Errorf("error open local file %s, %w", f.FileID, errCtx)
+	}
+
+	file, errOpen := os.Open(f.FilePath)
+	if errOpen != nil {
+		return nil, false, fmt.Errorf("error open local file %s, %w", f.FileID, errOpen)
+	}
+
+	if offset > 0 {
+		if _, errSeek := file.Seek(offset, io.SeekStart); errSeek != nil {
+			_ = file.Close()
+			return nil, false, fmt.Errorf("error seek local file %s, %w", f.FileID, errSeek)
+		}
+	}
+
+	return &contextReadCloser{ctx: ctx, ReadCloser: file}, offset > 0, nil
+}
+
+// contextReadCloser returns the context error from Read when the context is done
+type contextReadCloser struct {
+	ctx context.Context
+	io.ReadCloser
+}
+
+func (r *contextReadCloser) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.ReadCloser.Read(p)
+}
+
+// copyFileContent copies src to dst and returns ErrorFileTooLarge if written plus already copied bytes exceed maxSize
+func copyFileContent(dst io.Writer, src io.Reader, maxSize, written int64) (int64, error) {
+	if maxSize > 0 {
+		src = io.LimitReader(src, maxSize-written+1)
+	}
+
+	n, errCopy := io.Copy(dst, src)
+	if errCopy != nil {
+		return n, fmt.Errorf("error read file content, %w", errCopy)
+	}
+
+	if maxSize > 0 && written+n > maxSize {
+		return n, fmt.Errorf("%w, max size %d", ErrorFileTooLarge, maxSize)
+	}
+
+	return n, nil
+}
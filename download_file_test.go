@@ -0,0 +1,173 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-telegram/bot/models"
+)
+
+type fileServerMock struct {
+	s           *httptest.Server
+	content     []byte
+	ranges      []string
+	getFileCall int
+}
+
+func newFileServerMock(content []byte) *fileServerMock {
+	m := &fileServerMock{content: content}
+	m.s = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/botXXX/getFile":
+			m.getFileCall++
+			_, _ = rw.Write([]byte(`{"ok":true,"result":{"file_id":"foo","file_path":"documents/file.txt"}}`))
+		case "/file/botXXX/documents/file.txt":
+			m.ranges = append(m.ranges, req.Header.Get("Range"))
+			http.ServeContent(rw, req, "file.txt", time.Time{}, bytes.NewReader(m.content))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return m
+}
+
+func (m *fileServerMock) bot() *Bot {
+	return &Bot{url: m.s.URL, token: "XXX", client: http.DefaultClient}
+}
+
+func TestBot_DownloadFile(t *testing.T) {
+	content := []byte(strings.Repeat("foobar", 1000))
+	m := newFileServerMock(content)
+	defer m.s.Close()
+
+	buf := bytes.NewBuffer(nil)
+	sum := sha256.Sum256(content)
+
+	f, err := m.bot().DownloadFile(context.Background(), &DownloadFileParams{FileID: "foo", SHA256: sum[:]}, buf)
+	assertNoErr(t, err)
+	assertEqualString(t, f.FilePath, "documents/file.txt")
+	assertEqualInt(t, m.getFileCall, 1)
+	if !bytes.Equal(buf.Bytes(), content) {
+		t.Fatal("unexpected content")
+	}
+}
+
+func TestBot_DownloadFile_File(t *testing.T) {
+	m := newFileServerMock([]byte("foo"))
+	defer m.s.Close()
+
+	buf := bytes.NewBuffer(nil)
+
+	_, err := m.bot().DownloadFile(context.Background(), &DownloadFileParams{File: &models.File{FileID: "foo", FilePath: "documents/file.txt"}}, buf)
+	assertNoErr(t, err)
+	assertEqualInt(t, m.getFileCall, 0)
+	assertEqualString(t, buf.String(), "foo")
+}
+
+func TestBot_DownloadFile_MaxSize(t *testing.T) {
+	m := newFileServerMock([]byte("foobar"))
+	defer m.s.Close()
+
+	_, err := m.bot().DownloadFile(context.Background(), &DownloadFileParams{FileID: "foo", MaxSize: 5}, bytes.NewBuffer(nil))
+	if !errors.Is(err, ErrorFileTooLarge) {
+		t.Fatalf("expected ErrorFileTooLarge, got %v", err)
+	}
+
+	_, err = m.bot().DownloadFile(context.Background(), &DownloadFileParams{
+		File:    &models.File{FileID: "foo", FilePath: "documents/file.txt", FileSize: 10},
+		MaxSize: 5,
+	}, bytes.NewBuffer(nil))
+	if !errors.Is(err, ErrorFileTooLarge) {
+		t.Fatalf("expected ErrorFileTooLarge, got %v", err)
+	}
+	assertEqualInt(t, len(m.ranges), 1)
+}
+
+func TestBot_DownloadFile_ChecksumMismatch(t *testing.T) {
+	m := newFileServerMock([]byte("foobar"))
+	defer m.s.Close()
+
+	_, err := m.bot().DownloadFile(context.Background(), &DownloadFileParams{FileID: "foo", SHA256: []byte("bad")}, bytes.NewBuffer(nil))
+	if !errors.Is(err, ErrorChecksumMismatch) {
+		t.Fatalf("expected ErrorChecksumMismatch, got %v", err)
+	}
+}
+
+func TestBot_DownloadFileToPath_Resume(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), downloadResumeOverlap/5)
+	m := newFileServerMock(content)
+	defer m.s.Close()
+
+	path := filepath.Join(t.TempDir(), "file.txt")
+	if err := os.WriteFile(path, content[:len(content)-100], 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	sum := sha256.Sum256(content)
+
+	_, err := m.bot().DownloadFileToPath(context.Background(), &DownloadFileParams{FileID: "foo", SHA256: sum[:]}, path)
+	assertNoErr(t, err)
+
+	if len(m.ranges) != 1 || m.ranges[0] == "" {
+		t.Fatalf("expected one range request, got %q", m.ranges)
+	}
+
+	data, _ := os.ReadFile(path)
+	if !bytes.Equal(data, content) {
+		t.Fatal("unexpected content")
+	}
+}
+
+func TestBot_DownloadFileToPath_ResumeMismatch(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), downloadResumeOverlap/5)
+	m := newFileServerMock(content)
+	defer m.s.Close()
+
+	local := bytes.Repeat([]byte("x"), len(content)-100)
+	path := filepath.Join(t.TempDir(), "file.txt")
+	if err := os.WriteFile(path, local, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := m.bot().DownloadFileToPath(context.Background(), &DownloadFileParams{FileID: "foo"}, path)
+	assertNoErr(t, err)
+
+	if len(m.ranges) != 2 || m.ranges[1] != "" {
+		t.Fatalf("expected range request and full request, got %q", m.ranges)
+	}
+
+	data, _ := os.ReadFile(path)
+	if !bytes.Equal(data, content) {
+		t.Fatal("unexpected content")
+	}
+}
+
+func TestBot_DownloadFileToTemp(t *testing.T) {
+	m := newFileServerMock([]byte("foobar"))
+	defer m.s.Close()
+
+	dir := t.TempDir()
+
+	path, err := m.bot().DownloadFileToTemp(context.Background(), &DownloadFileParams{FileID: "foo"}, dir, "download-*")
+	assertNoErr(t, err)
+
+	data, _ := os.ReadFile(path)
+	assertEqualString(t, string(data), "foobar")
+
+	_, err = m.bot().DownloadFileToTemp(context.Background(), &DownloadFileParams{FileID: "foo", SHA256: []byte("bad")}, dir, "download-*")
+	if !errors.Is(err, ErrorChecksumMismatch) {
+		t.Fatalf("expected ErrorChecksumMismatch, got %v", err)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	assertEqualInt(t, len(entries), 1)
+}
@@ -12,6 +12,10 @@ var (
 	ErrorTooManyRequests = errors.New("too many requests")
 	ErrorNotFound        = errors.New("not found")
 	ErrorConflict        = errors.New("conflict")
+
+	ErrorFileTooLarge     = errors.New("file too large")
+	ErrorChecksumMismatch = errors.New("checksum mismatch")
+	ErrorValidation       = errors.New("validation error")
 )
 
 type TooManyRequestsError struct {
@@ -41,3 +45,23 @@ func IsMigrateError(err error) bool {
 	_, ok := err.(*MigrateError)
 	return ok
 }
+
+// ValidationError is returned before the request is sent if params are invalid, see WithParamsValidation.
+// errors.Is(err, ErrorValidation) reports true for it
+type ValidationError struct {
+	Method string
+	// Field is the json name of the invalid field, empty if the error is returned by ParamsValidator
+	Field  string
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("%s: invalid params for method %s, %s", ErrorValidation, e.Method, e.Reason)
+	}
+	return fmt.Sprintf("%s: invalid params for method %s, %s %s", ErrorValidation, e.Method, e.Field, e.Reason)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrorValidation
+}
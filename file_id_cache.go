@@ -0,0 +1,287 @@
+package bot
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/go-telegram/bot/models"
+)
+
+// FileIDCache stores file_id values of uploaded files by the upload content key
+type FileIDCache interface {
+	Get(ctx context.Context, key string) (fileID string, ok bool, err error)
+	Set(ctx context.Context, key, fileID string) error
+	Delete(ctx context.Context, key string) error
+}
+
+// MemoryFileIDCache is an in-memory FileIDCache
+type MemoryFileIDCache struct {
+	mx    sync.RWMutex
+	items map[string]string
+}
+
+// NewMemoryFileIDCache returns new in-memory FileIDCache
+func NewMemoryFileIDCache() *MemoryFileIDCache {
+	return &MemoryFileIDCache{items: map[string]string{}}
+}
+
+func (c *MemoryFileIDCache) Get(_ context.Context, key string) (string, bool, error) {
+	c.mx.RLock()
+	defer c.mx.RUnlock()
+
+	fileID, ok := c.items[key]
+	return fileID, ok, nil
+}
+
+func (c *MemoryFileIDCache) Set(_ context.Context, key, fileID string) error {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	c.items[key] = fileID
+	return nil
+}
+
+func (c *MemoryFileIDCache) Delete(_ context.Context, key string) error {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	delete(c.items, key)
+	return nil
+}
+
+// fileIDCacheFields maps params fields with files to upload to file_id values in the sent message
+var fileIDCacheFields = map[string]func(m *models.Message) string{
+	"photo": func(m *models.Message) string {
+		if len(m.Photo) == 0 {
+			return ""
+		}
+		return m.Photo[len(m.Photo)-1].FileID
+	},
+	"document": func(m *models.Message) string {
+		if m.Document == nil {
+			return ""
+		}
+		return m.Document.FileID
+	},
+	"video": func(m *models.Message) string {
+		if m.Video == nil {
+			return ""
+		}
+		return m.Video.FileID
+	},
+	"audio": func(m *models.Message) string {
+		if m.Audio == nil {
+			return ""
+		}
+		return m.Audio.FileID
+	},
+	"animation": func(m *models.Message) string {
+		if m.Animation == nil {
+			return ""
+		}
+		return m.Animation.FileID
+	},
+	"voice": func(m *models.Message) string {
+		if m.Voice == nil {
+			return ""
+		}
+		return m.Voice.FileID
+	},
+	"video_note": func(m *models.Message) string {
+		if m.VideoNote == nil {
+			return ""
+		}
+		return m.VideoNote.FileID
+	},
+	"sticker": func(m *models.Message) string {
+		if m.Sticker == nil {
+			return ""
+		}
+		return m.Sticker.FileID
+	},
+}
+
+// staleFileIDErrors are parts of Bot API error descriptions about a wrong file_id
+var staleFileIDErrors = []string{"file identifier", "file_id", "file id"}
+
+// cacheableUpload is a file to upload, which file_id can be taken from the sent message
+type cacheableUpload struct {
+	fieldIdx  int
+	fieldName string
+	upload    *models.InputFileUpload
+	data      io.ReadSeeker
+}
+
+// findCacheableUpload returns the media file to upload if dest is a message.
+// Uploads from readers, which are not io.Seeker, are not cached: hashing them would read the whole file into memory
+func findCacheableUpload(params any, dest any) (cacheableUpload, bool) {
+	if _, ok := resultMessage(dest); !ok || params == nil {
+		return cacheableUpload{}, false
+	}
+
+	rv := reflect.ValueOf(params)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return cacheableUpload{}, false
+	}
+
+	v := rv.Elem()
+	if v.Kind() != reflect.Struct {
+		return cacheableUpload{}, false
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		fieldName := strings.Split(v.Type().Field(i).Tag.Get("json"), ",")[0]
+		if _, ok := fileIDCacheFields[fieldName]; !ok {
+			continue
+		}
+		upload, ok := v.Field(i).Interface().(*models.InputFileUpload)
+		if !ok || upload == nil {
+			continue
+		}
+		data, ok := upload.Data.(io.ReadSeeker)
+		if !ok {
+			return cacheableUpload{}, false
+		}
+		return cacheableUpload{fieldIdx: i, fieldName: fieldName, upload: upload, data: data}, true
+	}
+
+	return cacheableUpload{}, false
+}
+
+// rawRequestFileIDCache sends cached file_id instead of the file content if the same content was uploaded before.
+// If Telegram rejects the cached file_id as wrong, the file is uploaded again.
+// The cached file_id is sent only if other files of params, like the thumbnail, can be sent again after the failed request
+func (b *Bot) rawRequestFileIDCache(ctx context.Context, method string, params any, dest any, u cacheableUpload) error {
+	start, errSeek := u.data.Seek(0, io.SeekCurrent)
+	if errSeek != nil {
+		return fmt.Errorf("error seek upload for method %s, %w", method, errSeek)
+	}
+
+	key, errHash := hashUpload(u, start)
+	if errHash != nil {
+		return fmt.Errorf("error hash upload for method %s, %w", method, errHash)
+	}
+
+	rewind, canRetry := otherUploadsRewinder(params, u.fieldIdx)
+
+	fileID, ok, errGet := b.fileIDCache.Get(ctx, key)
+	if errGet != nil {
+		b.error("error get file id from cache, %w", errGet)
+	}
+	if ok && errGet == nil && canRetry {
+		err := b.doRequest(ctx, method, replaceParamsField(params, u.fieldIdx, &models.InputFileString{Data: fileID}), dest)
+		if !isStaleFileIDError(err) {
+			return err
+		}
+		// file_id is stale, upload the file again
+		if errDelete := b.fileIDCache.Delete(ctx, key); errDelete != nil {
+			b.error("error delete file id from cache, %w", errDelete)
+		}
+		if errRewind := rewind(); errRewind != nil {
+			return fmt.Errorf("error seek upload for method %s, %w", method, errRewind)
+		}
+	}
+
+	if _, errSeek = u.data.Seek(start, io.SeekStart); errSeek != nil {
+		return fmt.Errorf("error seek upload for method %s, %w", method, errSeek)
+	}
+
+	err := b.doRequest(ctx, method, params, dest)
+	if err != nil {
+		return err
+	}
+
+	if m, _ := resultMessage(dest); m != nil {
+		if fileID = fileIDCacheFields[u.fieldName](m); fileID != "" {
+			if errSet := b.fileIDCache.Set(ctx, key, fileID); errSet != nil {
+				b.error("error set file id to cache, %w", errSet)
+			}
+		}
+	}
+
+	return nil
+}
+
+// isStaleFileIDError reports whether Telegram rejected the request because of a wrong file_id
+func isStaleFileIDError(err error) bool {
+	if !errors.Is(err, ErrorBadRequest) {
+		return false
+	}
+
+	description := strings.ToLower(err.Error())
+	for _, s := range staleFileIDErrors {
+		if strings.Contains(description, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hashUpload returns the cache key of the upload content, the content is read from the start offset
+func hashUpload(u cacheableUpload, start int64) (string, error) {
+	h := sha256.New()
+	if _, errCopy := io.Copy(h, u.data); errCopy != nil {
+		return "", errCopy
+	}
+	if _, errSeek := u.data.Seek(start, io.SeekStart); errSeek != nil {
+		return "", errSeek
+	}
+
+	return u.fieldName + ":" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// otherUploadsRewinder returns a function, which seeks other files to upload of params back to their current offsets.
+// Returns false if some of them are not io.Seeker and can't be sent again
+func otherUploadsRewinder(params any, skipIdx int) (func() error, bool) {
+	type position struct {
+		rs     io.Seeker
+		offset int64
+	}
+	var positions []position
+
+	v := reflect.ValueOf(params).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if i == skipIdx || v.Field(i).Kind() != reflect.Interface || v.Field(i).IsNil() {
+			continue
+		}
+		upload, ok := v.Field(i).Interface().(*models.InputFileUpload)
+		if !ok || upload == nil {
+			continue
+		}
+		rs, ok := upload.Data.(io.Seeker)
+		if !ok {
+			return nil, false
+		}
+		offset, errSeek := rs.Seek(0, io.SeekCurrent)
+		if errSeek != nil {
+			return nil, false
+		}
+		positions = append(positions, position{rs: rs, offset: offset})
+	}
+
+	return func() error {
+		for _, p := range positions {
+			if _, err := p.rs.Seek(p.offset, io.SeekStart); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, true
+}
+
+// replaceParamsField returns a copy of params with the field replaced by value
+func replaceParamsField(params any, fieldIdx int, value any) any {
+	v := reflect.ValueOf(params).Elem()
+	c := reflect.New(v.Type())
+	c.Elem().Set(v)
+	c.Elem().Field(fieldIdx).Set(reflect.ValueOf(value))
+	return c.Interface()
+}
@@ -0,0 +1,166 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-telegram/bot/models"
+)
+
+type fileIDServerMock struct {
+	s          *httptest.Server
+	uploads    int
+	fileIDs    []string
+	thumbnails []string
+	// errors are descriptions of errors returned for file_id values
+	errors map[string]string
+}
+
+func newFileIDServerMock() *fileIDServerMock {
+	m := &fileIDServerMock{errors: map[string]string{}}
+	m.s = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if thumb, _, err := req.FormFile("thumbnail"); err == nil {
+			data, _ := io.ReadAll(thumb)
+			m.thumbnails = append(m.thumbnails, string(data))
+		}
+		for _, field := range []string{"photo", "video"} {
+			if _, _, err := req.FormFile(field); err == nil {
+				m.uploads++
+				_, _ = rw.Write([]byte(`{"ok":true,"result":{"photo":[{"file_id":"small"},{"file_id":"big"}],"video":{"file_id":"video"}}}`))
+				return
+			}
+		}
+		fields, _ := requestFields(req)
+		fileID := fields["photo"] + fields["video"]
+		m.fileIDs = append(m.fileIDs, fileID)
+		if description, ok := m.errors[fileID]; ok {
+			_, _ = rw.Write([]byte(`{"ok":false,"error_code":400,"description":"` + description + `"}`))
+			return
+		}
+		_, _ = rw.Write([]byte(`{"ok":true,"result":{"photo":[{"file_id":"big"}],"video":{"file_id":"video"}}}`))
+	}))
+	return m
+}
+
+func TestBot_FileIDCache(t *testing.T) {
+	m := newFileIDServerMock()
+	defer m.s.Close()
+
+	cache := NewMemoryFileIDCache()
+	b := &Bot{url: m.s.URL, token: "XXX", client: http.DefaultClient, fileIDCache: cache}
+
+	params := &SendPhotoParams{ChatID: 1, Photo: &models.InputFileUpload{Filename: "foo.png", Data: strings.NewReader("foo")}}
+
+	_, err := b.SendPhoto(context.Background(), params)
+	assertNoErr(t, err)
+	assertEqualInt(t, m.uploads, 1)
+
+	// the same content
+	_, err = b.SendPhoto(context.Background(), &SendPhotoParams{ChatID: 1, Photo: &models.InputFileUpload{Filename: "bar.png", Data: strings.NewReader("foo")}})
+	assertNoErr(t, err)
+	assertEqualInt(t, m.uploads, 1)
+	if len(m.fileIDs) != 1 || m.fileIDs[0] != "big" {
+		t.Fatalf("unexpected file ids %q", m.fileIDs)
+	}
+
+	// the same content from a non-seekable reader is not cached
+	_, err = b.SendPhoto(context.Background(), &SendPhotoParams{ChatID: 1, Photo: &models.InputFileUpload{Filename: "bar.png", Data: bytes.NewBufferString("foo")}})
+	assertNoErr(t, err)
+	assertEqualInt(t, m.uploads, 2)
+
+	if _, ok := params.Photo.(*models.InputFileUpload); !ok {
+		t.Fatal("params must not be changed")
+	}
+
+	// other content
+	_, err = b.SendPhoto(context.Background(), &SendPhotoParams{ChatID: 1, Photo: &models.InputFileUpload{Filename: "foo.png", Data: strings.NewReader("bar")}})
+	assertNoErr(t, err)
+	assertEqualInt(t, m.uploads, 3)
+}
+
+func TestBot_FileIDCache_Stale(t *testing.T) {
+	m := newFileIDServerMock()
+	defer m.s.Close()
+
+	cache := NewMemoryFileIDCache()
+	b := &Bot{url: m.s.URL, token: "XXX", client: http.DefaultClient, fileIDCache: cache}
+
+	_, err := b.SendPhoto(context.Background(), &SendPhotoParams{ChatID: 1, Photo: &models.InputFileUpload{Filename: "foo.png", Data: strings.NewReader("foo")}})
+	assertNoErr(t, err)
+
+	m.errors["big"] = "Bad Request: wrong file identifier/HTTP URL specified"
+
+	_, err = b.SendPhoto(context.Background(), &SendPhotoParams{ChatID: 1, Photo: &models.InputFileUpload{Filename: "foo.png", Data: strings.NewReader("foo")}})
+	assertNoErr(t, err)
+	assertEqualInt(t, m.uploads, 2)
+	assertEqualInt(t, len(m.fileIDs), 1)
+}
+
+func TestBot_FileIDCache_OtherBadRequest(t *testing.T) {
+	m := newFileIDServerMock()
+	defer m.s.Close()
+
+	cache := NewMemoryFileIDCache()
+	b := &Bot{url: m.s.URL, token: "XXX", client: http.DefaultClient, fileIDCache: cache}
+
+	_, err := b.SendPhoto(context.Background(), &SendPhotoParams{ChatID: 1, Photo: &models.InputFileUpload{Filename: "foo.png", Data: strings.NewReader("foo")}})
+	assertNoErr(t, err)
+
+	m.errors["big"] = "Bad Request: chat not found"
+
+	_, err = b.SendPhoto(context.Background(), &SendPhotoParams{ChatID: 1, Photo: &models.InputFileUpload{Filename: "foo.png", Data: strings.NewReader("foo")}})
+	if !errors.Is(err, ErrorBadRequest) {
+		t.Fatalf("expected ErrorBadRequest, got %v", err)
+	}
+	assertEqualInt(t, m.uploads, 1)
+
+	fileID, ok, _ := cache.Get(context.Background(), "photo:"+sha256Hex("foo"))
+	assertTrue(t, ok)
+	assertEqualString(t, fileID, "big")
+}
+
+func TestBot_FileIDCache_Stale_Thumbnail(t *testing.T) {
+	m := newFileIDServerMock()
+	defer m.s.Close()
+
+	cache := NewMemoryFileIDCache()
+	b := &Bot{url: m.s.URL, token: "XXX", client: http.DefaultClient, fileIDCache: cache}
+
+	_, err := b.SendVideo(context.Background(), &SendVideoParams{ChatID: 1, Video: &models.InputFileUpload{Filename: "foo.mp4", Data: strings.NewReader("foo")}})
+	assertNoErr(t, err)
+
+	m.errors["video"] = "Bad Request: wrong file identifier/HTTP URL specified"
+
+	_, err = b.SendVideo(context.Background(), &SendVideoParams{
+		ChatID:    1,
+		Video:     &models.InputFileUpload{Filename: "foo.mp4", Data: strings.NewReader("foo")},
+		Thumbnail: &models.InputFileUpload{Filename: "thumb.jpg", Data: strings.NewReader("thumb")},
+	})
+	assertNoErr(t, err)
+	assertEqualInt(t, m.uploads, 2)
+	if len(m.thumbnails) != 2 || m.thumbnails[0] != "thumb" || m.thumbnails[1] != "thumb" {
+		t.Fatalf("unexpected thumbnails %q", m.thumbnails)
+	}
+
+	// the thumbnail from a non-seekable reader can't be sent twice, the cached file_id is not used
+	_, err = b.SendVideo(context.Background(), &SendVideoParams{
+		ChatID:    1,
+		Video:     &models.InputFileUpload{Filename: "foo.mp4", Data: strings.NewReader("foo")},
+		Thumbnail: &models.InputFileUpload{Filename: "thumb.jpg", Data: bytes.NewBufferString("thumb")},
+	})
+	assertNoErr(t, err)
+	assertEqualInt(t, m.uploads, 3)
+}
+
+func sha256Hex(s string) string {
+	h := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(h[:])
+}
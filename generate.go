@@ -0,0 +1,3 @@
+package bot
+
+//go:generate go run ./internal/apigen -spec internal/apigen/botapi.json -methods methods.go -check .
@@ -3,7 +3,6 @@ package bot
 import (
 	"context"
 	"errors"
-	"sync"
 	"sync/atomic"
 	"time"
 
@@ -23,16 +22,18 @@ type getUpdatesParams struct {
 
 type AllowedUpdates []string
 
-// GetUpdates https://core.telegram.org/bots/api#getupdates
-func (b *Bot) getUpdates(ctx context.Context, wg *sync.WaitGroup) {
-	defer wg.Done()
+// pollUpdates calls getUpdates https://core.telegram.org/bots/api#getupdates until ctx is done.
+// Returns true if polling is stopped on conflict, see WithStopPollingOnConflict
+func (b *Bot) pollUpdates(ctx context.Context) bool {
+	b.setPolling(true)
+	defer b.setPolling(false)
 
 	var timeoutAfterError time.Duration
 
 	for {
 		select {
 		case <-ctx.Done():
-			return
+			return false
 		default:
 		}
 
@@ -42,7 +43,7 @@ func (b *Bot) getUpdates(ctx context.Context, wg *sync.WaitGroup) {
 			}
 			select {
 			case <-ctx.Done():
-				return
+				return false
 			case <-time.After(timeoutAfterError):
 			}
 		}
@@ -61,23 +62,45 @@ func (b *Bot) getUpdates(ctx context.Context, wg *sync.WaitGroup) {
 		errRequest := b.rawRequest(ctx, "getUpdates", params, &updates)
 		if errRequest != nil {
 			if errors.Is(errRequest, context.Canceled) {
-				return
+				return false
 			}
 			b.error("error get updates, %w", errRequest)
+			b.metrics.pollingError()
+			if errors.Is(errRequest, ErrorConflict) {
+				if b.conflictHandler != nil {
+					b.conflictHandler(errRequest)
+				}
+				if b.stopPollingOnConflict {
+					b.error("polling is stopped on conflict")
+					return true
+				}
+			}
 			timeoutAfterError = incErrTimeout(timeoutAfterError)
 			continue
 		}
 
 		timeoutAfterError = 0
+		b.setLastPoll()
 
 		for _, upd := range updates {
-			atomic.StoreInt64(&b.lastUpdateID, upd.ID)
+			if b.diskQueue != nil {
+				// the update is acknowledged by the next getUpdates offset only after it is written to the disk
+				if err := b.diskQueue.add(upd); err != nil {
+					b.error("error write update to disk queue, %w", err)
+					timeoutAfterError = incErrTimeout(timeoutAfterError)
+					break
+				}
+				atomic.StoreInt64(&b.lastUpdateID, upd.ID)
+				continue
+			}
+
 			select {
 			case <-ctx.Done():
 				b.error("some updates lost, ctx done")
-				return
+				return false
 			case b.updates <- upd:
 			}
+			atomic.StoreInt64(&b.lastUpdateID, upd.ID)
 		}
 	}
 }
@@ -0,0 +1,127 @@
+package bot
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultRequestTimeout  = time.Second * 30
+	defaultUploadTimeout   = time.Minute * 10
+	defaultDownloadTimeout = time.Minute * 10
+)
+
+// ConnectionPool configures the connection pool of the default http client.
+// Zero fields keep the values of http.DefaultTransport
+type ConnectionPool struct {
+	// MaxIdleConns limits idle connections to all hosts
+	MaxIdleConns int
+	// MaxIdleConnsPerHost limits idle connections to the Bot API server. http.DefaultTransport keeps 2
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost limits all connections to the Bot API server, including active ones
+	MaxConnsPerHost int
+	// IdleConnTimeout is how long an idle connection is kept in the pool
+	IdleConnTimeout time.Duration
+}
+
+// newHTTPClient returns the default http client with own connection pool
+func newHTTPClient(timeout time.Duration, pool ConnectionPool) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if pool.MaxIdleConns > 0 {
+		transport.MaxIdleConns = pool.MaxIdleConns
+	}
+	if pool.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = pool.MaxIdleConnsPerHost
+	}
+	if pool.MaxConnsPerHost > 0 {
+		transport.MaxConnsPerHost = pool.MaxConnsPerHost
+	}
+	if pool.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = pool.IdleConnTimeout
+	}
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	}
+}
+
+// initHTTPClients creates default clients, which are not set by options.
+// A client set by WithHTTPClient is used for polling too, unless WithPollingClient is used
+func (b *Bot) initHTTPClients() {
+	if b.pollClient == nil {
+		if b.client != nil {
+			b.pollClient = b.client
+		} else {
+			b.pollClient = newHTTPClient(b.pollTimeout, b.pollingConnectionPool)
+		}
+	}
+
+	if b.client == nil {
+		// timeouts of API calls are set per request, see requestTimeout
+		b.client = newHTTPClient(0, b.connectionPool)
+	}
+
+	if b.downloadClient == nil {
+		b.downloadClient = withoutClientTimeout(b.client)
+	}
+}
+
+// withoutClientTimeout returns the copy of *http.Client without Timeout, which shares the transport.
+// Downloads are limited by WithDownloadTimeout, not by the timeout of the client set by WithHTTPClient
+func withoutClientTimeout(client HttpClient) HttpClient {
+	c, ok := client.(*http.Client)
+	if !ok || c.Timeout == 0 {
+		return client
+	}
+
+	copied := *c
+	copied.Timeout = 0
+	return &copied
+}
+
+// httpClient returns the client for the method: the polling client for getUpdates and the API client for others
+func (b *Bot) httpClient(method string) HttpClient {
+	if method == "getUpdates" && b.pollClient != nil {
+		return b.pollClient
+	}
+
+	return b.client
+}
+
+// fileDownloadClient returns the client for file downloads
+func (b *Bot) fileDownloadClient() HttpClient {
+	if b.downloadClient != nil {
+		return b.downloadClient
+	}
+
+	return b.client
+}
+
+// requestTimeout returns the timeout of the method call, 0 means no timeout.
+// getUpdates is limited by the polling client timeout only, unless set by WithMethodTimeout
+func (b *Bot) requestTimeout(method string, upload bool) time.Duration {
+	if timeout, ok := b.methodTimeouts[method]; ok {
+		return timeout
+	}
+
+	switch {
+	case method == "getUpdates":
+		return 0
+	case upload:
+		return b.uploadTimeout
+	default:
+		return b.apiRequestTimeout
+	}
+}
+
+// withTimeout returns ctx with the timeout, if it is set
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
@@ -0,0 +1,111 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestNew_HTTPClients(t *testing.T) {
+	b, err := New("xxx", WithSkipGetMe(),
+		WithConnectionPool(ConnectionPool{MaxIdleConnsPerHost: 10, MaxConnsPerHost: 20}),
+		WithPollingConnectionPool(ConnectionPool{IdleConnTimeout: time.Second}),
+	)
+	assertNoErr(t, err)
+
+	client := b.client.(*http.Client)
+	pollClient := b.pollClient.(*http.Client)
+
+	assertTrue(t, client != pollClient)
+	assertTrue(t, client.Timeout == 0)
+	assertTrue(t, pollClient.Timeout == defaultPollTimeout)
+
+	transport := client.Transport.(*http.Transport)
+	assertEqualInt(t, transport.MaxIdleConnsPerHost, 10)
+	assertEqualInt(t, transport.MaxConnsPerHost, 20)
+
+	pollTransport := pollClient.Transport.(*http.Transport)
+	assertTrue(t, transport != pollTransport)
+	assertTrue(t, pollTransport.IdleConnTimeout == time.Second)
+	assertTrue(t, http.DefaultTransport.(*http.Transport).MaxConnsPerHost == 0)
+
+	custom := &httpClient{t: t, resp: `{}`}
+
+	b, err = New("xxx", WithSkipGetMe(), WithHTTPClient(time.Second, custom))
+	assertNoErr(t, err)
+	assertTrue(t, b.client == custom)
+	assertTrue(t, b.pollClient == custom)
+
+	customPoll := &httpClient{t: t, resp: `[]`}
+
+	b, err = New("xxx", WithSkipGetMe(), WithPollingClient(time.Second, customPoll), WithHTTPClient(time.Second, custom))
+	assertNoErr(t, err)
+	assertTrue(t, b.httpClient("getUpdates") == customPoll)
+	assertTrue(t, b.httpClient("sendMessage") == custom)
+}
+
+func TestBot_DownloadClientTimeout(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		time.Sleep(time.Millisecond * 200)
+		_, _ = w.Write([]byte("foo"))
+	}))
+	defer s.Close()
+
+	client := &http.Client{Timeout: time.Millisecond * 100}
+
+	b, err := New("xxx", WithSkipGetMe(), WithServerURL(s.URL), WithHTTPClient(time.Millisecond*50, client))
+	assertNoErr(t, err)
+	assertTrue(t, b.httpClient("sendMessage") == client)
+	assertTrue(t, b.fileDownloadClient().(*http.Client).Timeout == 0)
+
+	buf := &strings.Builder{}
+	_, err = b.DownloadFile(context.Background(), &DownloadFileParams{File: &models.File{FileID: "foo", FilePath: "documents/file.txt"}}, buf)
+	assertNoErr(t, err)
+	assertEqualString(t, buf.String(), "foo")
+}
+
+func TestBot_requestTimeout(t *testing.T) {
+	b, err := New("xxx", WithSkipGetMe(), WithMethodTimeout("sendVideo", time.Hour), WithUploadTimeout(time.Minute))
+	assertNoErr(t, err)
+
+	assertTrue(t, b.requestTimeout("sendMessage", false) == defaultRequestTimeout)
+	assertTrue(t, b.requestTimeout("sendPhoto", true) == time.Minute)
+	assertTrue(t, b.requestTimeout("sendVideo", true) == time.Hour)
+	assertTrue(t, b.requestTimeout("getUpdates", false) == 0)
+}
+
+func TestBot_RequestTimeout(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if strings.HasSuffix(req.URL.Path, "/sendMessage") {
+			select {
+			case <-req.Context().Done():
+				return
+			case <-time.After(time.Millisecond * 200):
+			}
+		}
+		_, _ = rw.Write([]byte(`{"ok":true,"result":{}}`))
+	}))
+	defer s.Close()
+
+	b, err := New("xxx", WithSkipGetMe(), WithServerURL(s.URL), WithRequestTimeout(time.Millisecond*50))
+	assertNoErr(t, err)
+
+	_, err = b.SendMessage(context.Background(), &SendMessageParams{ChatID: 1, Text: "text"})
+	assertTrue(t, errors.Is(err, context.DeadlineExceeded))
+
+	_, err = b.GetMe(context.Background())
+	assertNoErr(t, err)
+
+	b, err = New("xxx", WithSkipGetMe(), WithServerURL(s.URL), WithRequestTimeout(time.Millisecond*50),
+		WithMethodTimeout("sendMessage", time.Second))
+	assertNoErr(t, err)
+
+	_, err = b.SendMessage(context.Background(), &SendMessageParams{ChatID: 1, Text: "text"})
+	assertNoErr(t, err)
+}
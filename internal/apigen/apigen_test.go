@@ -0,0 +1,195 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func loadTestSpec(t *testing.T) *Spec {
+	t.Helper()
+
+	page, err := os.ReadFile("testdata/api.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := parseDocs(string(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return s
+}
+
+func TestParseDocs(t *testing.T) {
+	s := loadTestSpec(t)
+
+	if s.Version != "8.2" {
+		t.Fatalf("unexpected version %q", s.Version)
+	}
+	if len(s.Types) != 8 || len(s.Methods) != 5 {
+		t.Fatalf("unexpected number of types %d and methods %d", len(s.Types), len(s.Methods))
+	}
+
+	returns := map[string]string{}
+	for _, m := range s.Methods {
+		returns[m.Name] = m.Returns
+	}
+	expected := map[string]string{
+		"getMe":           "User",
+		"sendMessage":     "Message",
+		"getUpdates":      "Array of Update",
+		"editMessageText": "Message or True",
+		"deleteMessage":   "True",
+	}
+	for name, r := range expected {
+		if returns[name] != r {
+			t.Errorf("unexpected result of %s %q, expected %q", name, returns[name], r)
+		}
+	}
+
+	user, _ := s.findType("User")
+	if user.Fields[1].Description != "User's or bot's first name" || !user.Fields[1].Required || user.Fields[2].Required {
+		t.Errorf("unexpected User fields %+v", user.Fields)
+	}
+
+	reaction, _ := s.findType("ReactionType")
+	if strings.Join(reaction.OneOf, ",") != "ReactionTypeEmoji,ReactionTypeCustomEmoji" {
+		t.Errorf("unexpected ReactionType members %v", reaction.OneOf)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	files, err := generate(loadTestSpec(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	generated := map[string]string{}
+	for _, f := range files {
+		generated[f.Path] = string(f.Content)
+	}
+
+	for path, substrings := range map[string][]string{
+		"methods.go": {
+			"// Code generated by apigen from Bot API 8.2. DO NOT EDIT.",
+			"func (b *Bot) GetMe(ctx context.Context) (*models.User, error) {\n\tresult := &models.User{}\n\terr := b.rawRequest(ctx, \"getMe\", nil, result)",
+			"func (b *Bot) EditMessageText(ctx context.Context, params *EditMessageTextParams) (*models.Message, error) {\n\tresult := &messageOrTrue{}",
+			"func (b *Bot) DeleteMessage(ctx context.Context, params *DeleteMessageParams) (bool, error) {\n\tvar result bool",
+		},
+		"methods_params.go": {
+			"// SendMessageParams https://core.telegram.org/bots/api#sendmessage",
+			"ChatID      any                `json:\"chat_id\"`",
+			"ParseMode   models.ParseMode   `json:\"parse_mode,omitempty\"`",
+			"ReplyMarkup models.ReplyMarkup `json:\"reply_markup,omitempty\"`",
+		},
+		"models/types.go": {
+			"ID        int64  `json:\"id\"`",
+			"From      *User           `json:\"from,omitempty\"`",
+			"CustomEmojiID string `json:\"custom_emoji_id,omitempty\"`",
+			"ReactionTypeTypeCustomEmoji ReactionTypeType = \"custom_emoji\"",
+			"Type  ReactionTypeType `json:\"type\"`",
+			"case \"custom_emoji\":\n\t\tu.Type = ReactionTypeTypeCustomEmoji",
+		},
+	} {
+		for _, substr := range substrings {
+			if !strings.Contains(generated[path], substr) {
+				t.Errorf("%s does not contain %q\n%s", path, substr, generated[path])
+			}
+		}
+	}
+
+	if strings.Contains(generated["models/types.go"], "type InputFile") {
+		t.Error("hand-written InputFile is generated")
+	}
+	if strings.Contains(generated["methods.go"], "GetUpdates") || strings.Contains(generated["methods_params.go"], "GetUpdatesParams") {
+		t.Error("hand-written getUpdates is generated")
+	}
+}
+
+func TestGenerate_Snapshot(t *testing.T) {
+	s, err := loadSpec("botapi.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := generate(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	methods, err := os.ReadFile("../../methods.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range files {
+		if f.Path == "methods.go" && string(f.Content) != string(methods) {
+			t.Fatal("methods.go differs from the generated code, run go generate")
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	s, err := loadSpec("botapi.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	problems, err := check(s, "../..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) > 0 {
+		t.Fatalf("sources differ from the spec\n%s", strings.Join(problems, "\n"))
+	}
+
+	for i, m := range s.Methods {
+		if m.Name == "sendMessage" {
+			s.Methods[i].Params = append(m.Params[1:], Field{Name: "message_effect_id", Type: "String", Required: true})
+		}
+	}
+	for i, tp := range s.Types {
+		if tp.Name == "MessageId" {
+			s.Types[i].Fields[0].Required = false
+		}
+	}
+
+	problems, err = check(s, "../..")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"method sendMessage: required field message_effect_id of SendMessageParams has omitempty",
+		"method sendMessage: unknown field business_connection_id in SendMessageParams",
+		"type MessageId: optional field message_id of MessageID has no omitempty",
+	}
+	if strings.Join(problems, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("unexpected problems\n%s", strings.Join(problems, "\n"))
+	}
+}
+
+func TestDiff(t *testing.T) {
+	old := loadTestSpec(t)
+	s := loadTestSpec(t)
+
+	s.Version = "8.3"
+	s.Methods = s.Methods[1:]
+	s.Methods[0].Params = append(s.Methods[0].Params, Field{Name: "message_effect_id", Type: "String"})
+	s.Methods[0].Params[0].Type = "Integer"
+	s.Types = append(s.Types, Type{Name: "Gift"})
+
+	expected := []string{
+		"~ version 8.2 -> 8.3",
+		"~ method sendMessage: param chat_id type Integer or String -> Integer",
+		"~ method sendMessage: + param message_effect_id String (optional)",
+		"- method getMe",
+		"+ type Gift",
+	}
+
+	changes := diff(old, s)
+	if strings.Join(changes, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("unexpected changes\n%s", strings.Join(changes, "\n"))
+	}
+}
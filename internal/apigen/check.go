@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// check returns differences between the spec and hand-written params structs in dir and models in dir/models:
+// missing and unknown fields and optional fields without omitempty or required fields with it.
+// Go types of fields are not checked, because hand-written types are often more specific than the spec
+func check(s *Spec, dir string) ([]string, error) {
+	params, errParams := readSources(dir)
+	if errParams != nil {
+		return nil, errParams
+	}
+	models, errModels := readSources(filepath.Join(dir, "models"))
+	if errModels != nil {
+		return nil, errModels
+	}
+
+	discriminators := unionFields(s)
+
+	var problems []string
+
+	for _, m := range s.Methods {
+		if len(m.Params) == 0 || handWrittenMethods[m.Name] {
+			continue
+		}
+		name := methodName(m.Name) + "Params"
+		st, ok := params.structs[name]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("method %s: no struct %s", m.Name, name))
+			continue
+		}
+		problems = append(problems, checkFields("method "+m.Name, st, m.Params, "")...)
+	}
+
+	for _, t := range s.Types {
+		if handWrittenTypes[t.Name] || len(t.OneOf) > 0 {
+			continue
+		}
+		name := goTypeName(t.Name)
+		st, ok := models.structs[name]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("type %s: no struct models.%s", t.Name, name))
+			continue
+		}
+		// the discriminator of union members is often written by custom marshalers
+		problems = append(problems, checkFields("type "+t.Name, st, t.Fields, discriminators[t.Name])...)
+	}
+
+	return problems, nil
+}
+
+// unionFields returns the discriminator field of union members by the member name.
+// It is the first field, which all members of the union have
+func unionFields(s *Spec) map[string]string {
+	result := map[string]string{}
+
+	for _, t := range s.Types {
+		var field string
+		for i, member := range t.OneOf {
+			mt, ok := s.findType(member)
+			if !ok || len(mt.Fields) == 0 || (i > 0 && mt.Fields[0].Name != field) {
+				field = ""
+				break
+			}
+			field = mt.Fields[0].Name
+		}
+		if field == "" {
+			continue
+		}
+		for _, member := range t.OneOf {
+			result[member] = field
+		}
+	}
+
+	return result
+}
+
+func checkFields(prefix string, st goStruct, fields []Field, skip string) []string {
+	var problems []string
+
+	goFields := map[string]goField{}
+	for _, f := range st.Fields {
+		goFields[f.Name] = f
+	}
+	known := map[string]bool{}
+
+	for _, f := range fields {
+		known[f.Name] = true
+		gf, ok := goFields[f.Name]
+		switch {
+		case !ok && f.Name != skip:
+			problems = append(problems, fmt.Sprintf("%s: no field %s in %s", prefix, f.Name, st.Name))
+		case ok && gf.Required && !f.Required:
+			problems = append(problems, fmt.Sprintf("%s: optional field %s of %s has no omitempty", prefix, f.Name, st.Name))
+		case ok && !gf.Required && f.Required:
+			problems = append(problems, fmt.Sprintf("%s: required field %s of %s has omitempty", prefix, f.Name, st.Name))
+		}
+	}
+	for _, f := range st.Fields {
+		if !known[f.Name] {
+			problems = append(problems, fmt.Sprintf("%s: unknown field %s in %s", prefix, f.Name, st.Name))
+		}
+	}
+
+	return problems
+}
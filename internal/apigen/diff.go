@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diff returns changes of methods and types between the old and the new spec, one change per line
+func diff(old, new *Spec) []string {
+	var changes []string
+
+	if old.Version != new.Version {
+		changes = append(changes, fmt.Sprintf("~ version %s -> %s", old.Version, new.Version))
+	}
+
+	oldMethods := map[string]Method{}
+	for _, m := range old.Methods {
+		oldMethods[m.Name] = m
+	}
+	newMethods := map[string]bool{}
+
+	for _, m := range new.Methods {
+		newMethods[m.Name] = true
+
+		o, ok := oldMethods[m.Name]
+		if !ok {
+			changes = append(changes, fmt.Sprintf("+ method %s", m.Name))
+			continue
+		}
+		if o.Returns != m.Returns {
+			changes = append(changes, fmt.Sprintf("~ method %s: returns %s -> %s", m.Name, o.Returns, m.Returns))
+		}
+		changes = append(changes, diffFields("method "+m.Name, "param", o.Params, m.Params)...)
+	}
+	for _, m := range old.Methods {
+		if !newMethods[m.Name] {
+			changes = append(changes, fmt.Sprintf("- method %s", m.Name))
+		}
+	}
+
+	oldTypes := map[string]Type{}
+	for _, t := range old.Types {
+		oldTypes[t.Name] = t
+	}
+	newTypes := map[string]bool{}
+
+	for _, t := range new.Types {
+		newTypes[t.Name] = true
+
+		o, ok := oldTypes[t.Name]
+		if !ok {
+			changes = append(changes, fmt.Sprintf("+ type %s", t.Name))
+			continue
+		}
+		if oldOneOf, newOneOf := strings.Join(o.OneOf, ", "), strings.Join(t.OneOf, ", "); oldOneOf != newOneOf {
+			changes = append(changes, fmt.Sprintf("~ type %s: one of %s -> %s", t.Name, oldOneOf, newOneOf))
+		}
+		changes = append(changes, diffFields("type "+t.Name, "field", o.Fields, t.Fields)...)
+	}
+	for _, t := range old.Types {
+		if !newTypes[t.Name] {
+			changes = append(changes, fmt.Sprintf("- type %s", t.Name))
+		}
+	}
+
+	return changes
+}
+
+func diffFields(owner, kind string, old, new []Field) []string {
+	var changes []string
+
+	oldFields := map[string]Field{}
+	for _, f := range old {
+		oldFields[f.Name] = f
+	}
+	newFields := map[string]bool{}
+
+	for _, f := range new {
+		newFields[f.Name] = true
+
+		o, ok := oldFields[f.Name]
+		if !ok {
+			changes = append(changes, fmt.Sprintf("~ %s: + %s %s %s%s", owner, kind, f.Name, f.Type, optional(f)))
+			continue
+		}
+		if o.Type != f.Type {
+			changes = append(changes, fmt.Sprintf("~ %s: %s %s type %s -> %s", owner, kind, f.Name, o.Type, f.Type))
+		}
+		if o.Required != f.Required {
+			changes = append(changes, fmt.Sprintf("~ %s: %s %s required %t -> %t", owner, kind, f.Name, o.Required, f.Required))
+		}
+	}
+	for _, f := range old {
+		if !newFields[f.Name] {
+			changes = append(changes, fmt.Sprintf("~ %s: - %s %s", owner, kind, f.Name))
+		}
+	}
+
+	return changes
+}
+
+func optional(f Field) string {
+	if f.Required {
+		return ""
+	}
+	return " (optional)"
+}
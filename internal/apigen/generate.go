@@ -0,0 +1,410 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const docsURL = "https://core.telegram.org/bots/api#"
+
+const replyMarkupTypes = "InlineKeyboardMarkup or ReplyKeyboardMarkup or ReplyKeyboardRemove or ForceReply"
+
+// handWrittenTypes are Bot API types implemented by hand in the models package
+var handWrittenTypes = map[string]bool{
+	"InputFile": true,
+}
+
+// handWrittenMethods are Bot API methods implemented by hand in the bot package
+var handWrittenMethods = map[string]bool{
+	"getUpdates": true,
+}
+
+// typeNames are Go names of Bot API types, which differ from the API names
+var typeNames = map[string]string{
+	"MessageId": "MessageID",
+	"LoginUrl":  "LoginURL",
+}
+
+// methodNames are Go names of Bot API methods, which differ from the API names
+var methodNames = map[string]string{
+	"logOut": "Logout",
+}
+
+// resultTypes are Go result types of methods, which differ from the types built from the spec.
+// They are kept for compatibility with wrappers written before the generator
+var resultTypes = map[string]string{
+	"sendMediaGroup":            "[]*models.Message",
+	"getForumTopicIconStickers": "[]*models.Sticker",
+	"getCustomEmojiStickers":    "[]*models.Sticker",
+	"getGameHighScores":         "[]*models.GameHighScore",
+	"getMyName":                 "models.BotName",
+	"getMyDescription":          "models.BotDescription",
+	"getMyShortDescription":     "models.BotShortDescription",
+	"getChatMenuButton":         "models.MenuButton",
+}
+
+var reAlways = regexp.MustCompile(`always [“"]([^”"]+)[”"]`)
+
+var initialisms = map[string]string{
+	"id":     "ID",
+	"ids":    "IDs",
+	"url":    "URL",
+	"ip":     "IP",
+	"json":   "JSON",
+	"http":   "HTTP",
+	"https":  "HTTPS",
+	"api":    "API",
+	"uri":    "URI",
+	"html":   "HTML",
+	"sha256": "SHA256",
+}
+
+// File is a generated Go source file
+type File struct {
+	Path    string
+	Content []byte
+}
+
+// discriminator is the field, which value selects the member of the union type
+type discriminator struct {
+	field string
+	union string
+	value string
+}
+
+type generator struct {
+	spec           *Spec
+	discriminators map[string]discriminator
+}
+
+// generate returns method wrappers, params structs and models generated from the spec
+func generate(s *Spec) ([]File, error) {
+	g := &generator{spec: s, discriminators: map[string]discriminator{}}
+
+	for _, t := range s.Types {
+		for member, d := range g.unionDiscriminators(t) {
+			g.discriminators[member] = d
+		}
+	}
+
+	var files []File
+
+	for _, f := range []struct {
+		path string
+		gen  func() []byte
+	}{
+		{path: "methods.go", gen: g.methods},
+		{path: "methods_params.go", gen: g.params},
+		{path: "models/types.go", gen: g.models},
+	} {
+		src, errFormat := format.Source(f.gen())
+		if errFormat != nil {
+			return nil, fmt.Errorf("error format %s, %w", f.path, errFormat)
+		}
+		files = append(files, File{Path: f.path, Content: src})
+	}
+
+	return files, nil
+}
+
+func (g *generator) methods() []byte {
+	body := &bytes.Buffer{}
+
+	for _, m := range g.spec.Methods {
+		if handWrittenMethods[m.Name] {
+			continue
+		}
+
+		name := methodName(m.Name)
+		resultType, isPointer := g.resultType(m.Returns)
+		if t, ok := resultTypes[m.Name]; ok {
+			resultType, isPointer = t, strings.HasPrefix(t, "*")
+		}
+
+		// methods returning the message or True for inline messages return nil message for inline messages
+		messageOrTrue := resultType == "*models.Message" && strings.HasSuffix(m.Returns, " or True")
+
+		fmt.Fprintf(body, "\n// %s %s%s\n", name, docsURL, strings.ToLower(m.Name))
+		if messageOrTrue {
+			body.WriteString("//\n// The message is nil for inline messages\n")
+		}
+
+		params := "params"
+		if len(m.Params) == 0 {
+			params = "nil"
+			fmt.Fprintf(body, "func (b *Bot) %s(ctx context.Context) (%s, error) {\n", name, resultType)
+		} else {
+			fmt.Fprintf(body, "func (b *Bot) %s(ctx context.Context, params *%sParams) (%s, error) {\n", name, name, resultType)
+		}
+
+		if messageOrTrue {
+			body.WriteString("\tresult := &messageOrTrue{}\n")
+			fmt.Fprintf(body, "\terr := b.rawRequest(ctx, %q, %s, result)\n", m.Name, params)
+			body.WriteString("\treturn result.message, err\n}\n")
+			continue
+		}
+
+		if isPointer {
+			fmt.Fprintf(body, "\tresult := &%s{}\n", strings.TrimPrefix(resultType, "*"))
+			fmt.Fprintf(body, "\terr := b.rawRequest(ctx, %q, %s, result)\n", m.Name, params)
+		} else {
+			fmt.Fprintf(body, "\tvar result %s\n", resultType)
+			fmt.Fprintf(body, "\terr := b.rawRequest(ctx, %q, %s, &result)\n", m.Name, params)
+		}
+		body.WriteString("\treturn result, err\n}\n")
+	}
+
+	return g.file("bot", body.Bytes(), "context")
+}
+
+func (g *generator) params() []byte {
+	body := &bytes.Buffer{}
+
+	for _, m := range g.spec.Methods {
+		if len(m.Params) == 0 || handWrittenMethods[m.Name] {
+			continue
+		}
+
+		name := methodName(m.Name) + "Params"
+
+		fmt.Fprintf(body, "\n// %s %s%s\n", name, docsURL, strings.ToLower(m.Name))
+		fmt.Fprintf(body, "type %s struct {\n", name)
+		for _, f := range m.Params {
+			g.writeField(body, f, g.goType(f.Type, f, "models."))
+		}
+		body.WriteString("}\n")
+	}
+
+	return g.file("bot", body.Bytes())
+}
+
+func (g *generator) models() []byte {
+	body := &bytes.Buffer{}
+	var unions bool
+
+	for _, t := range g.spec.Types {
+		if handWrittenTypes[t.Name] {
+			continue
+		}
+		if len(t.OneOf) > 0 {
+			unions = g.writeUnion(body, t) || unions
+			continue
+		}
+
+		fmt.Fprintf(body, "\n// %s %s%s\n", goTypeName(t.Name), docsURL, strings.ToLower(t.Name))
+		fmt.Fprintf(body, "type %s struct {\n", goTypeName(t.Name))
+		for _, f := range t.Fields {
+			goType := g.goType(f.Type, f, "")
+			if d, ok := g.discriminators[t.Name]; ok && d.field == f.Name {
+				goType = d.union + "Type"
+			}
+			g.writeField(body, f, goType)
+		}
+		body.WriteString("}\n")
+	}
+
+	if unions {
+		return g.file("models", body.Bytes(), "encoding/json", "fmt")
+	}
+	return g.file("models", body.Bytes())
+}
+
+// writeUnion writes the union type with JSON (un)marshalers, which select the member by the discriminator field.
+// Returns false if members have no common discriminator, then the union type is any
+func (g *generator) writeUnion(body *bytes.Buffer, t Type) bool {
+	d, ok := g.discriminators[t.OneOf[0]]
+	if !ok || d.union != t.Name {
+		fmt.Fprintf(body, "\n// %s %s%s\n// can be one of %s\n", t.Name, docsURL, strings.ToLower(t.Name), strings.Join(t.OneOf, ", "))
+		fmt.Fprintf(body, "type %s any\n", t.Name)
+		return false
+	}
+
+	enum := t.Name + "Type"
+
+	fmt.Fprintf(body, "\n// %s %s%s\n", enum, docsURL, strings.ToLower(t.Name))
+	fmt.Fprintf(body, "type %s string\n\nconst (\n", enum)
+	for _, member := range t.OneOf {
+		fmt.Fprintf(body, "\t%s%s %s = %q\n", enum, exportedName(g.discriminators[member].value), enum, g.discriminators[member].value)
+	}
+	body.WriteString(")\n")
+
+	fmt.Fprintf(body, "\n// %s %s%s\n", t.Name, docsURL, strings.ToLower(t.Name))
+	fmt.Fprintf(body, "type %s struct {\n\tType %s\n\n", t.Name, enum)
+	for _, member := range t.OneOf {
+		fmt.Fprintf(body, "\t%s *%s\n", goTypeName(member), goTypeName(member))
+	}
+	body.WriteString("}\n")
+
+	fmt.Fprintf(body, "\nfunc (u *%s) MarshalJSON() ([]byte, error) {\n\tswitch u.Type {\n", t.Name)
+	for _, member := range t.OneOf {
+		c := enum + exportedName(g.discriminators[member].value)
+		fmt.Fprintf(body, "\tcase %s:\n\t\tu.%s.%s = %s\n\t\treturn json.Marshal(u.%s)\n", c, goTypeName(member), exportedName(d.field), c, goTypeName(member))
+	}
+	fmt.Fprintf(body, "\t}\n\n\treturn nil, fmt.Errorf(\"unsupported %s type\")\n}\n", t.Name)
+
+	fmt.Fprintf(body, "\nfunc (u *%s) UnmarshalJSON(data []byte) error {\n", t.Name)
+	fmt.Fprintf(body, "\tv := struct {\n\t\tType string `json:%q`\n\t}{}\n", d.field)
+	body.WriteString("\tif err := json.Unmarshal(data, &v); err != nil {\n\t\treturn err\n\t}\n\n\tswitch v.Type {\n")
+	for _, member := range t.OneOf {
+		value := g.discriminators[member].value
+		fmt.Fprintf(body, "\tcase %q:\n\t\tu.Type = %s%s\n\t\tu.%s = &%s{}\n\t\treturn json.Unmarshal(data, u.%s)\n",
+			value, enum, exportedName(value), goTypeName(member), goTypeName(member), goTypeName(member))
+	}
+	fmt.Fprintf(body, "\t}\n\n\treturn fmt.Errorf(\"unsupported %s type\")\n}\n", t.Name)
+
+	return true
+}
+
+// unionDiscriminators returns discriminators of the union type members,
+// or nil if members do not have a common field with a constant value
+func (g *generator) unionDiscriminators(t Type) map[string]discriminator {
+	if len(t.OneOf) == 0 {
+		return nil
+	}
+
+	result := map[string]discriminator{}
+	var field string
+
+	for _, member := range t.OneOf {
+		mt, ok := g.spec.findType(member)
+		if !ok || len(mt.Fields) == 0 {
+			return nil
+		}
+		m := reAlways.FindStringSubmatch(mt.Fields[0].Description)
+		if m == nil || (field != "" && field != mt.Fields[0].Name) {
+			return nil
+		}
+		field = mt.Fields[0].Name
+		result[member] = discriminator{field: field, union: t.Name, value: m[1]}
+	}
+
+	return result
+}
+
+func (g *generator) writeField(body *bytes.Buffer, f Field, goType string) {
+	tag := f.Name
+	if !f.Required {
+		tag += ",omitempty"
+	}
+	fmt.Fprintf(body, "\t%s %s `json:%q`\n", exportedName(f.Name), goType, tag)
+}
+
+// goType returns the Go type of the field, prefix is added to types of the models package
+func (g *generator) goType(apiType string, f Field, prefix string) string {
+	if strings.HasPrefix(apiType, "Array of ") {
+		return "[]" + g.goType(strings.TrimPrefix(apiType, "Array of "), Field{Name: f.Name, Required: true, Description: f.Description}, prefix)
+	}
+
+	switch apiType {
+	case "Integer":
+		if strings.Contains(f.Description, "64-bit") || strings.Contains(f.Description, "52 significant bits") {
+			return "int64"
+		}
+		return "int"
+	case "Float", "Float number":
+		return "float64"
+	case "String":
+		if f.Name == "parse_mode" {
+			return prefix + "ParseMode"
+		}
+		return "string"
+	case "Boolean", "True":
+		return "bool"
+	case "Integer or String":
+		return "any"
+	case "InputFile", "InputFile or String":
+		return prefix + "InputFile"
+	case replyMarkupTypes:
+		return prefix + "ReplyMarkup"
+	}
+
+	t, ok := g.spec.findType(apiType)
+	if !ok {
+		return "any"
+	}
+	if !f.Required && len(t.OneOf) == 0 {
+		return "*" + prefix + goTypeName(t.Name)
+	}
+	return prefix + goTypeName(t.Name)
+}
+
+// resultType returns the Go type of the method result and whether it is a pointer to a struct
+func (g *generator) resultType(returns string) (string, bool) {
+	// methods returning a message or True for inline messages return the message
+	types := strings.Split(returns, " or ")
+	if len(types) > 1 && types[len(types)-1] == "True" {
+		types = types[:len(types)-1]
+	}
+	if len(types) > 1 {
+		return "any", false
+	}
+
+	goType := g.goType(types[0], Field{Required: true}, "models.")
+	if _, ok := g.spec.findType(types[0]); ok && !strings.HasPrefix(goType, "[]") {
+		return "*" + goType, true
+	}
+
+	return goType, false
+}
+
+func (g *generator) file(pkg string, body []byte, imports ...string) []byte {
+	src := &bytes.Buffer{}
+
+	fmt.Fprintf(src, "// Code generated by apigen from Bot API %s. DO NOT EDIT.\n\npackage %s\n\n", g.spec.Version, pkg)
+
+	if pkg != "models" && bytes.Contains(body, []byte("models.")) {
+		imports = append(imports, "github.com/go-telegram/bot/models")
+	}
+	if len(imports) > 0 {
+		src.WriteString("import (\n")
+		for i, imp := range imports {
+			// the standard library group is separated from module imports like goimports does
+			if i > 0 && !strings.Contains(imports[i-1], ".") && strings.Contains(imp, ".") {
+				src.WriteString("\n")
+			}
+			fmt.Fprintf(src, "\t%q\n", imp)
+		}
+		src.WriteString(")\n")
+	}
+
+	src.Write(body)
+
+	return src.Bytes()
+}
+
+// methodName returns the Go name of the method
+func methodName(name string) string {
+	if n, ok := methodNames[name]; ok {
+		return n
+	}
+	return exportedName(name)
+}
+
+// goTypeName returns the Go name of the type
+func goTypeName(name string) string {
+	if n, ok := typeNames[name]; ok {
+		return n
+	}
+	return name
+}
+
+// exportedName converts snake_case and camelCase names to exported Go names
+func exportedName(name string) string {
+	var result strings.Builder
+
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '-' || r == ' ' }) {
+		if v, ok := initialisms[strings.ToLower(part)]; ok {
+			result.WriteString(v)
+			continue
+		}
+		r := []rune(part)
+		r[0] = unicode.ToUpper(r[0])
+		result.WriteString(string(r))
+	}
+
+	return result.String()
+}
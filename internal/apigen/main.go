@@ -0,0 +1,122 @@
+// Command apigen generates Bot API method wrappers, params structs and models from a snapshot of the Bot API specification.
+//
+// Update the snapshot from the saved documentation page https://core.telegram.org/bots/api:
+//
+//	go run ./internal/apigen -parse api.html -spec internal/apigen/botapi.json
+//
+// Show what the new snapshot changes compared to the previous one:
+//
+//	go run ./internal/apigen -spec new.json -diff internal/apigen/botapi.json
+//
+// Generate the code to the directory:
+//
+//	go run ./internal/apigen -spec internal/apigen/botapi.json -out generated
+//
+// Regenerate methods.go of the library and check hand-written params structs and models against the snapshot,
+// this is what go generate runs in the root of the module:
+//
+//	go run ./internal/apigen -spec internal/apigen/botapi.json -methods methods.go -check .
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	specPath := flag.String("spec", "", "path to the spec snapshot in JSON")
+	parsePath := flag.String("parse", "", "path to the saved documentation page, the parsed spec is written to -spec")
+	diffPath := flag.String("diff", "", "path to the previous spec snapshot, changes are printed to stdout")
+	outDir := flag.String("out", "", "directory for generated files")
+	methodsPath := flag.String("methods", "", "path to write only the generated method wrappers to")
+	checkDir := flag.String("check", "", "directory of the library, params structs and models are checked against the spec")
+	flag.Parse()
+
+	if err := run(*specPath, *parsePath, *diffPath, *outDir, *methodsPath, *checkDir); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(specPath, parsePath, diffPath, outDir, methodsPath, checkDir string) error {
+	if specPath == "" {
+		return fmt.Errorf("-spec is required")
+	}
+
+	if parsePath != "" {
+		page, errRead := os.ReadFile(parsePath)
+		if errRead != nil {
+			return fmt.Errorf("error read documentation page, %w", errRead)
+		}
+		s, errParse := parseDocs(string(page))
+		if errParse != nil {
+			return errParse
+		}
+		return writeSpec(specPath, s)
+	}
+
+	s, errLoad := loadSpec(specPath)
+	if errLoad != nil {
+		return errLoad
+	}
+
+	if diffPath != "" {
+		old, errLoadOld := loadSpec(diffPath)
+		if errLoadOld != nil {
+			return errLoadOld
+		}
+		for _, change := range diff(old, s) {
+			fmt.Println(change)
+		}
+		return nil
+	}
+
+	if outDir == "" && methodsPath == "" && checkDir == "" {
+		return fmt.Errorf("one of -parse, -diff, -out, -methods or -check is required")
+	}
+
+	files, errGenerate := generate(s)
+	if errGenerate != nil {
+		return errGenerate
+	}
+
+	for _, f := range files {
+		if methodsPath == "" || f.Path != "methods.go" {
+			continue
+		}
+		if errWrite := os.WriteFile(methodsPath, f.Content, 0o644); errWrite != nil {
+			return fmt.Errorf("error write %s, %w", methodsPath, errWrite)
+		}
+	}
+
+	if checkDir != "" {
+		problems, errCheck := check(s, checkDir)
+		if errCheck != nil {
+			return errCheck
+		}
+		for _, p := range problems {
+			fmt.Fprintln(os.Stderr, p)
+		}
+		if len(problems) > 0 {
+			return fmt.Errorf("%d differences between the spec and the sources", len(problems))
+		}
+	}
+
+	if outDir == "" {
+		return nil
+	}
+
+	for _, f := range files {
+		path := filepath.Join(outDir, f.Path)
+		if errMkdir := os.MkdirAll(filepath.Dir(path), 0o755); errMkdir != nil {
+			return fmt.Errorf("error create directory for %s, %w", path, errMkdir)
+		}
+		if errWrite := os.WriteFile(path, f.Content, 0o644); errWrite != nil {
+			return fmt.Errorf("error write %s, %w", path, errWrite)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	reTag       = regexp.MustCompile(`<[^>]+>`)
+	reSpaces    = regexp.MustCompile(`\s+`)
+	reVersion   = regexp.MustCompile(`Bot API (\d+\.\d+)`)
+	reRow       = regexp.MustCompile(`(?s)<tr>(.*?)</tr>`)
+	reCell      = regexp.MustCompile(`(?s)<td>(.*?)</td>`)
+	reParagraph = regexp.MustCompile(`(?s)<p>(.*?)</p>`)
+	reListItem  = regexp.MustCompile(`(?s)<li>(.*?)</li>`)
+	reReturns   = regexp.MustCompile(`(?:Array of )*[A-Z][A-Za-z]+`)
+	reSentence  = regexp.MustCompile(`\.\s+`)
+)
+
+var returnPrimitives = map[string]string{
+	"True":    "True",
+	"Int":     "Integer",
+	"Integer": "Integer",
+	"String":  "String",
+	"Boolean": "Boolean",
+}
+
+// parseDocs parses the Bot API documentation page https://core.telegram.org/bots/api
+func parseDocs(page string) (*Spec, error) {
+	s := &Spec{}
+
+	if m := reVersion.FindStringSubmatch(page); m != nil {
+		s.Version = m[1]
+	}
+
+	type methodSection struct {
+		method Method
+		text   string
+	}
+	var methods []methodSection
+
+	for _, section := range splitSections(page) {
+		name := text(section.heading)
+		if name == "" || strings.ContainsAny(name, " \t") {
+			continue
+		}
+
+		description := sectionDescription(section.body)
+		rows := tableRows(section.body)
+
+		if unicode.IsLower(rune(name[0])) {
+			m := Method{Name: name, Description: description}
+			for _, cells := range rows {
+				if len(cells) != 4 {
+					return nil, fmt.Errorf("error parse method %s, unexpected table row %v", name, cells)
+				}
+				m.Params = append(m.Params, Field{
+					Name:        cells[0],
+					Type:        cells[1],
+					Required:    cells[2] == "Yes",
+					Description: cells[3],
+				})
+			}
+			methods = append(methods, methodSection{method: m, text: description})
+			continue
+		}
+
+		t := Type{Name: name, Description: description}
+		for _, cells := range rows {
+			if len(cells) != 3 {
+				return nil, fmt.Errorf("error parse type %s, unexpected table row %v", name, cells)
+			}
+			t.Fields = append(t.Fields, Field{
+				Name:        cells[0],
+				Type:        cells[1],
+				Required:    !strings.HasPrefix(cells[2], "Optional"),
+				Description: cells[2],
+			})
+		}
+		if len(rows) == 0 {
+			t.OneOf = unionMembers(section.body)
+		}
+		s.Types = append(s.Types, t)
+	}
+
+	for _, m := range methods {
+		m.method.Returns = parseReturns(s, m.text)
+		if m.method.Returns == "" {
+			return nil, fmt.Errorf("error parse method %s, unknown result type", m.method.Name)
+		}
+		s.Methods = append(s.Methods, m.method)
+	}
+
+	return s, nil
+}
+
+type section struct {
+	heading string
+	body    string
+}
+
+// splitSections splits the page by h4 headings, a section ends at the next h3 or h4 heading
+func splitSections(page string) []section {
+	var sections []section
+
+	parts := strings.Split(page, "<h4>")
+	for _, part := range parts[1:] {
+		heading, body, ok := strings.Cut(part, "</h4>")
+		if !ok {
+			continue
+		}
+		if i := strings.Index(body, "<h3>"); i >= 0 {
+			body = body[:i]
+		}
+		sections = append(sections, section{heading: heading, body: body})
+	}
+
+	return sections
+}
+
+// sectionDescription returns the text of paragraphs before the table
+func sectionDescription(body string) string {
+	if i := strings.Index(body, "<table"); i >= 0 {
+		body = body[:i]
+	}
+
+	var paragraphs []string
+	for _, m := range reParagraph.FindAllStringSubmatch(body, -1) {
+		paragraphs = append(paragraphs, text(m[1]))
+	}
+
+	return strings.Join(paragraphs, "\n")
+}
+
+func tableRows(body string) [][]string {
+	var rows [][]string
+
+	for _, row := range reRow.FindAllStringSubmatch(body, -1) {
+		cells := reCell.FindAllStringSubmatch(row[1], -1)
+		if len(cells) == 0 {
+			// header row
+			continue
+		}
+		values := make([]string, 0, len(cells))
+		for _, c := range cells {
+			values = append(values, text(c[1]))
+		}
+		rows = append(rows, values)
+	}
+
+	return rows
+}
+
+// unionMembers returns the list of types the union type can be one of
+func unionMembers(body string) []string {
+	i := strings.Index(body, "<ul>")
+	if i < 0 {
+		return nil
+	}
+
+	var members []string
+	for _, m := range reListItem.FindAllStringSubmatch(body[i:], -1) {
+		name := text(m[1])
+		if name == "" || strings.ContainsAny(name, " \t") || !unicode.IsUpper(rune(name[0])) {
+			return nil
+		}
+		members = append(members, name)
+	}
+
+	return members
+}
+
+// parseReturns finds the result type in sentences of the method description mentioning the result
+func parseReturns(s *Spec, description string) string {
+	var result []string
+	seen := map[string]bool{}
+
+	for _, sentence := range reSentence.Split(description, -1) {
+		if !strings.Contains(strings.ToLower(sentence), "return") {
+			continue
+		}
+		for _, word := range reReturns.FindAllString(sentence, -1) {
+			base := strings.TrimPrefix(word, strings.Repeat("Array of ", strings.Count(word, "Array of ")))
+			if p, ok := returnPrimitives[base]; ok {
+				word = strings.TrimSuffix(word, base) + p
+			} else if _, ok = s.findType(base); !ok {
+				continue
+			}
+			if !seen[word] {
+				seen[word] = true
+				result = append(result, word)
+			}
+		}
+	}
+
+	return strings.Join(result, " or ")
+}
+
+// text returns the html fragment as plain text
+func text(fragment string) string {
+	fragment = reTag.ReplaceAllString(fragment, "")
+	fragment = html.UnescapeString(fragment)
+	return strings.TrimSpace(reSpaces.ReplaceAllString(fragment, " "))
+}
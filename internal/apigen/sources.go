@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// goStruct is a struct declared in the library sources
+type goStruct struct {
+	Name   string
+	Fields []goField
+}
+
+// goField is a struct field with a json tag
+type goField struct {
+	Name     string
+	GoName   string
+	Type     ast.Expr
+	Required bool
+}
+
+// goSources are declarations of a package of the library
+type goSources struct {
+	files   map[string]*ast.File
+	structs map[string]goStruct
+	// order is the order of struct declarations in sorted files
+	order []string
+}
+
+// readSources parses non-test Go files of the package in dir
+func readSources(dir string) (*goSources, error) {
+	paths, errGlob := filepath.Glob(filepath.Join(dir, "*.go"))
+	if errGlob != nil {
+		return nil, fmt.Errorf("error list sources in %s, %w", dir, errGlob)
+	}
+	sort.Strings(paths)
+
+	src := &goSources{files: map[string]*ast.File{}, structs: map[string]goStruct{}}
+	fset := token.NewFileSet()
+
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		data, errRead := os.ReadFile(path)
+		if errRead != nil {
+			return nil, fmt.Errorf("error read %s, %w", path, errRead)
+		}
+		f, errParse := parser.ParseFile(fset, path, data, 0)
+		if errParse != nil {
+			return nil, fmt.Errorf("error parse %s, %w", path, errParse)
+		}
+		src.files[filepath.Base(path)] = f
+
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				st, ok := ts.Type.(*ast.StructType)
+				if !ok {
+					continue
+				}
+				src.structs[ts.Name.Name] = goStruct{Name: ts.Name.Name, Fields: jsonFields(st)}
+				src.order = append(src.order, ts.Name.Name)
+			}
+		}
+	}
+
+	return src, nil
+}
+
+// jsonFields returns fields of the struct, which have json tags
+func jsonFields(st *ast.StructType) []goField {
+	var fields []goField
+
+	for _, f := range st.Fields.List {
+		if f.Tag == nil || len(f.Names) == 0 {
+			continue
+		}
+		tag, errUnquote := strconv.Unquote(f.Tag.Value)
+		if errUnquote != nil {
+			continue
+		}
+		name, opts, _ := strings.Cut(reflect.StructTag(tag).Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		fields = append(fields, goField{
+			Name:     name,
+			GoName:   f.Names[0].Name,
+			Type:     f.Type,
+			Required: !strings.Contains(opts, "omitempty"),
+		})
+	}
+
+	return fields
+}
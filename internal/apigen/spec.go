@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Spec is a machine-readable snapshot of the Bot API documentation
+type Spec struct {
+	Version string   `json:"version"`
+	Methods []Method `json:"methods"`
+	Types   []Type   `json:"types"`
+}
+
+// Method is a Bot API method
+type Method struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description,omitempty"`
+	Returns     string  `json:"returns"`
+	Params      []Field `json:"params,omitempty"`
+}
+
+// Type is a Bot API object. Union types have OneOf instead of Fields
+type Type struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Fields      []Field  `json:"fields,omitempty"`
+	OneOf       []string `json:"one_of,omitempty"`
+}
+
+// Field is a method parameter or an object field.
+// Type is written like in the documentation, for example "Integer or String" or "Array of PhotoSize"
+type Field struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Required    bool   `json:"required"`
+	Description string `json:"description,omitempty"`
+}
+
+func (s *Spec) findType(name string) (Type, bool) {
+	for _, t := range s.Types {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return Type{}, false
+}
+
+func loadSpec(path string) (*Spec, error) {
+	data, errRead := os.ReadFile(path)
+	if errRead != nil {
+		return nil, fmt.Errorf("error read spec %s, %w", path, errRead)
+	}
+
+	s := &Spec{}
+	if errDecode := json.Unmarshal(data, s); errDecode != nil {
+		return nil, fmt.Errorf("error decode spec %s, %w", path, errDecode)
+	}
+
+	return s, nil
+}
+
+func writeSpec(path string, s *Spec) error {
+	data, errEncode := json.MarshalIndent(s, "", "  ")
+	if errEncode != nil {
+		return fmt.Errorf("error encode spec, %w", errEncode)
+	}
+
+	if errWrite := os.WriteFile(path, append(data, '\n'), 0o644); errWrite != nil {
+		return fmt.Errorf("error write spec %s, %w", path, errWrite)
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const defaultLeaderRetryInterval = time.Second
+
+var errLockBusy = errors.New("lock is held by another process")
+
+// LeaderElector elects one bot instance to poll updates, see WithLeaderElection
+type LeaderElector interface {
+	// Acquire blocks until the leadership is acquired or ctx is done.
+	// Returns the channel, which is closed when the leadership is lost
+	Acquire(ctx context.Context) (<-chan struct{}, error)
+	// Release gives up the leadership
+	Release() error
+}
+
+// FileLeaderElector is LeaderElector based on the exclusive lock of a local file.
+// The lock is released by the OS if the process exits
+type FileLeaderElector struct {
+	mx            sync.Mutex
+	path          string
+	retryInterval time.Duration
+	file          *os.File
+	lost          chan struct{}
+}
+
+// NewFileLeaderElector returns FileLeaderElector for the lock file path.
+// Instances on standby try to lock the file every retryInterval, 0 means 1 second
+func NewFileLeaderElector(path string, retryInterval time.Duration) *FileLeaderElector {
+	if retryInterval <= 0 {
+		retryInterval = defaultLeaderRetryInterval
+	}
+
+	return &FileLeaderElector{
+		path:          path,
+		retryInterval: retryInterval,
+	}
+}
+
+func (e *FileLeaderElector) Acquire(ctx context.Context) (<-chan struct{}, error) {
+	for {
+		lost, err := e.tryAcquire()
+		if err == nil {
+			return lost, nil
+		}
+		if !errors.Is(err, errLockBusy) {
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(e.retryInterval):
+		}
+	}
+}
+
+func (e *FileLeaderElector) tryAcquire() (<-chan struct{}, error) {
+	e.mx.Lock()
+	defer e.mx.Unlock()
+
+	if e.file != nil {
+		return e.lost, nil
+	}
+
+	f, errOpen := os.OpenFile(e.path, os.O_RDWR|os.O_CREATE, 0o600)
+	if errOpen != nil {
+		return nil, fmt.Errorf("error open lock file %s, %w", e.path, errOpen)
+	}
+
+	if err := lockFile(f); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	// the pid is written for information only
+	if err := f.Truncate(0); err == nil {
+		_, _ = f.WriteAt([]byte(strconv.Itoa(os.Getpid())), 0)
+	}
+
+	e.file = f
+	e.lost = make(chan struct{})
+
+	return e.lost, nil
+}
+
+func (e *FileLeaderElector) Release() error {
+	e.mx.Lock()
+	defer e.mx.Unlock()
+
+	if e.file == nil {
+		return nil
+	}
+
+	errUnlock := unlockFile(e.file)
+	errClose := e.file.Close()
+	close(e.lost)
+	e.file = nil
+
+	if errUnlock != nil {
+		return errUnlock
+	}
+	return errClose
+}
+
+// getUpdates polls updates. With leader election, polls only while the bot is the leader
+func (b *Bot) getUpdates(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	if b.leaderElector == nil {
+		b.pollUpdates(ctx)
+		return
+	}
+
+	var timeoutAfterError time.Duration
+
+	for {
+		if b.isDebug {
+			b.debugHandler("waiting for the leadership")
+		}
+
+		lost, errAcquire := b.leaderElector.Acquire(ctx)
+		if errAcquire != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			b.error("error acquire leadership, %w", errAcquire)
+			timeoutAfterError = incErrTimeout(timeoutAfterError)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(timeoutAfterError):
+			}
+			continue
+		}
+
+		timeoutAfterError = 0
+
+		if b.isDebug {
+			b.debugHandler("leadership acquired, start polling")
+		}
+
+		pollCtx, cancel := context.WithCancel(ctx)
+		go func() {
+			select {
+			case <-lost:
+				cancel()
+			case <-pollCtx.Done():
+			}
+		}()
+
+		stopped := b.pollUpdates(pollCtx)
+		cancel()
+
+		if err := b.leaderElector.Release(); err != nil {
+			b.error("error release leadership, %w", err)
+		}
+
+		if stopped || ctx.Err() != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,16 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package bot
+
+import (
+	"fmt"
+	"os"
+)
+
+func lockFile(_ *os.File) error {
+	return fmt.Errorf("file lock is not supported on this platform")
+}
+
+func unlockFile(_ *os.File) error {
+	return nil
+}
@@ -0,0 +1,130 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type conflictClientMock struct {
+	calls int64
+}
+
+func (c *conflictClientMock) Do(req *http.Request) (*http.Response, error) {
+	atomic.AddInt64(&c.calls, 1)
+	return &http.Response{
+		StatusCode: http.StatusConflict,
+		Body:       io.NopCloser(strings.NewReader(`{"ok":false,"error_code":409,"description":"Conflict: terminated by other getUpdates request"}`)),
+	}, nil
+}
+
+func TestFileLeaderElector(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bot.lock")
+
+	e1 := NewFileLeaderElector(path, time.Millisecond)
+	e2 := NewFileLeaderElector(path, time.Millisecond)
+
+	lost, err := e1.Acquire(context.Background())
+	assertNoErr(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+
+	_, err = e2.Acquire(ctx)
+	assertTrue(t, errors.Is(err, context.DeadlineExceeded))
+
+	assertNoErr(t, e1.Release())
+
+	select {
+	case <-lost:
+	default:
+		t.Fatal("lost channel is not closed after release")
+	}
+
+	_, err = e2.Acquire(context.Background())
+	assertNoErr(t, err)
+	assertNoErr(t, e2.Release())
+}
+
+func TestBot_StopPollingOnConflict(t *testing.T) {
+	client := &conflictClientMock{}
+
+	var conflicts int64
+	b, err := New("xxx",
+		WithSkipGetMe(),
+		WithHTTPClient(time.Second, client),
+		WithConflictHandler(func(err error) {
+			assertTrue(t, errors.Is(err, ErrorConflict))
+			atomic.AddInt64(&conflicts, 1)
+		}),
+		WithStopPollingOnConflict(),
+		WithErrorsHandler(func(err error) {}),
+	)
+	assertNoErr(t, err)
+
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+
+	done := make(chan struct{})
+	go func() {
+		b.getUpdates(context.Background(), wg)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("polling is not stopped")
+	}
+
+	assertEqualInt(t, int(atomic.LoadInt64(&client.calls)), 1)
+	assertEqualInt(t, int(atomic.LoadInt64(&conflicts)), 1)
+}
+
+func TestBot_LeaderElectionStandby(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bot.lock")
+
+	leader := NewFileLeaderElector(path, time.Millisecond)
+	_, err := leader.Acquire(context.Background())
+	assertNoErr(t, err)
+
+	client := &conflictClientMock{}
+
+	b, err := New("xxx",
+		WithSkipGetMe(),
+		WithHTTPClient(time.Second, client),
+		WithStopPollingOnConflict(),
+		WithLeaderElection(NewFileLeaderElector(path, time.Millisecond)),
+		WithErrorsHandler(func(err error) {}),
+	)
+	assertNoErr(t, err)
+
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+
+	done := make(chan struct{})
+	go func() {
+		b.getUpdates(context.Background(), wg)
+		close(done)
+	}()
+
+	time.Sleep(time.Millisecond * 50)
+	assertEqualInt(t, int(atomic.LoadInt64(&client.calls)), 0)
+
+	assertNoErr(t, leader.Release())
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("polling is not started after the leader released the lock")
+	}
+
+	assertEqualInt(t, int(atomic.LoadInt64(&client.calls)), 1)
+}
@@ -0,0 +1,70 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/go-telegram/bot/models"
+)
+
+// LocalFile returns InputFile with file:// URI of the local file path.
+// Works only with a local Bot API server, see WithLocalMode
+func LocalFile(path string) *models.InputFileString {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+
+	return &models.InputFileString{Data: "file://" + filepath.ToSlash(path)}
+}
+
+// IsLocalMode returns true if the bot works with a local Bot API server
+func (b *Bot) IsLocalMode() bool {
+	return b.localMode
+}
+
+// MigrateServer moves the bot to another Bot API server, then sets the new server url and local mode.
+// Before switching, the bot leaves the current server:
+//   - from the cloud server, the bot logs out with logOut. The bot can't log in back to the cloud server for 10 minutes
+//   - from a local server, to another local server or to the cloud server, the bot instance is closed with close,
+//     so the local server stops receiving updates for the bot. close fails with 429 in the first 10 minutes after the bot is launched
+//
+// Call it only when the bot is not running.
+// https://core.telegram.org/bots/api#logout https://core.telegram.org/bots/api#close
+func (b *Bot) MigrateServer(ctx context.Context, serverURL string, localMode bool) error {
+	if b.localMode {
+		// close is required to move from one local server to another, and stops the local instance before using the cloud server
+		if _, err := b.Close(ctx); err != nil {
+			return fmt.Errorf("error close bot on server %s, %w", b.url, err)
+		}
+	} else {
+		if _, err := b.Logout(ctx); err != nil {
+			return fmt.Errorf("error log out bot from server %s, %w", b.url, err)
+		}
+	}
+
+	b.url = serverURL
+	b.setLocalMode(localMode)
+
+	return nil
+}
+
+func (b *Bot) setLocalMode(localMode bool) {
+	b.localMode = localMode
+	b.setFileSizeLimits()
+}
+
+// setFileSizeLimits sets max upload and download sizes of the current server, if file size limits are enabled
+func (b *Bot) setFileSizeLimits() {
+	switch {
+	case !b.fileSizeLimits:
+		b.maxUploadSize = 0
+		b.maxDownloadSize = 0
+	case b.localMode:
+		b.maxUploadSize = localMaxUploadSize
+		b.maxDownloadSize = 0
+	default:
+		b.maxUploadSize = cloudMaxUploadSize
+		b.maxDownloadSize = cloudMaxDownloadSize
+	}
+}
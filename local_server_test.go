@@ -0,0 +1,117 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestLocalFile(t *testing.T) {
+	f := LocalFile("/tmp/foo.png")
+	assertEqualString(t, f.Data, "file:///tmp/foo.png")
+
+	f = LocalFile("foo.png")
+	if !strings.HasPrefix(f.Data, "file:///") || !strings.HasSuffix(f.Data, "/foo.png") {
+		t.Fatalf("unexpected data %q", f.Data)
+	}
+}
+
+func TestBot_MigrateServer(t *testing.T) {
+	var paths []string
+	s := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		paths = append(paths, req.URL.Path)
+		_, _ = rw.Write([]byte(`{"ok":true,"result":true}`))
+	}))
+	defer s.Close()
+
+	b, err := New("XXX", WithServerURL(s.URL), WithSkipGetMe())
+	assertNoErr(t, err)
+
+	err = b.MigrateServer(context.Background(), s.URL+"/local", true)
+	assertNoErr(t, err)
+	assertTrue(t, b.IsLocalMode())
+
+	err = b.MigrateServer(context.Background(), s.URL, false)
+	assertNoErr(t, err)
+	assertTrue(t, !b.IsLocalMode())
+
+	if len(paths) != 2 || paths[0] != "/botXXX/logOut" || paths[1] != "/local/botXXX/close" {
+		t.Fatalf("unexpected requests %q", paths)
+	}
+}
+
+func TestBot_DownloadFile_LocalMode(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file.txt")
+	if err := os.WriteFile(path, []byte("foobar"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := New("XXX", WithLocalMode(), WithSkipGetMe(), WithHTTPClient(0, &clientMock{}))
+	assertNoErr(t, err)
+
+	buf := bytes.NewBuffer(nil)
+	_, err = b.DownloadFile(context.Background(), &DownloadFileParams{File: &models.File{FilePath: path, FileSize: 100 << 20}}, buf)
+	assertNoErr(t, err)
+	assertEqualString(t, buf.String(), "foobar")
+}
+
+func TestBot_FileSizeLimits(t *testing.T) {
+	b, err := New("XXX", WithSkipGetMe(), WithFileSizeLimits(), WithHTTPClient(0, &httpClient{t: t, resp: `{}`}))
+	assertNoErr(t, err)
+
+	_, err = b.DownloadFile(context.Background(), &DownloadFileParams{File: &models.File{FilePath: "foo", FileSize: cloudMaxDownloadSize + 1}}, bytes.NewBuffer(nil))
+	if !errors.Is(err, ErrorFileTooLarge) {
+		t.Fatalf("expected ErrorFileTooLarge, got %v", err)
+	}
+
+	upload := &SendDocumentParams{
+		ChatID:   1,
+		Document: &models.InputFileUpload{Filename: "foo", Data: bytes.NewReader(make([]byte, cloudMaxUploadSize+1))},
+	}
+	_, err = b.SendDocument(context.Background(), upload)
+	if !errors.Is(err, ErrorFileTooLarge) {
+		t.Fatalf("expected ErrorFileTooLarge, got %v", err)
+	}
+
+	b.setLocalMode(true)
+	_, err = b.SendDocument(context.Background(), upload)
+	assertNoErr(t, err)
+}
+
+func TestBot_FileSizeLimits_Disabled(t *testing.T) {
+	b, err := New("XXX", WithSkipGetMe(), WithHTTPClient(0, &httpClient{t: t, resp: `{}`}))
+	assertNoErr(t, err)
+
+	upload := &SendDocumentParams{
+		ChatID:   1,
+		Document: &models.InputFileUpload{Filename: "foo", Data: bytes.NewReader(make([]byte, cloudMaxUploadSize+1))},
+	}
+	_, err = b.SendDocument(context.Background(), upload)
+	assertNoErr(t, err)
+}
+
+func TestBot_DownloadFile_LocalMode_Canceled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file.txt")
+	if err := os.WriteFile(path, []byte("foobar"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := New("XXX", WithLocalMode(), WithSkipGetMe(), WithHTTPClient(0, &clientMock{}))
+	assertNoErr(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = b.DownloadFile(ctx, &DownloadFileParams{File: &models.File{FilePath: path}}, bytes.NewBuffer(nil))
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot/models"
+)
+
+const redactedToken = "<token>"
+
+// structuredLogger logs Bot API requests and processed updates with structured fields, see WithLogger
+type structuredLogger interface {
+	logRequest(ctx context.Context, method string, params any, d time.Duration, err error)
+	logUpdate(ctx context.Context, upd *models.Update, d time.Duration, err error)
+}
+
+// redactToken replaces the bot token in s
+func (b *Bot) redactToken(s string) string {
+	if b.token == "" {
+		return s
+	}
+	return strings.ReplaceAll(s, b.token, redactedToken)
+}
+
+// redactURLError replaces the bot token in the url of *url.Error, which contains the request url with the token
+func (b *Bot) redactURLError(err error) error {
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return err
+	}
+
+	urlErr.URL = b.redactToken(urlErr.URL)
+
+	return err
+}
+
+// paramsChatID returns the value of ChatID field of the params struct, or nil
+func paramsChatID(params any) any {
+	v := reflect.ValueOf(params)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return nil
+	}
+	v = v.Elem()
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	f := v.FieldByName("ChatID")
+	if !f.IsValid() || f.IsZero() {
+		return nil
+	}
+
+	return f.Interface()
+}
+
+// updateChatID returns the id of the chat the update belongs to
+func updateChatID(upd *models.Update) (int64, bool) {
+	if m := updateMessage(upd); m != nil {
+		return m.Chat.ID, true
+	}
+
+	switch {
+	case upd.CallbackQuery != nil && upd.CallbackQuery.Message.InaccessibleMessage != nil:
+		return upd.CallbackQuery.Message.InaccessibleMessage.Chat.ID, true
+	case upd.MyChatMember != nil:
+		return upd.MyChatMember.Chat.ID, true
+	case upd.ChatMember != nil:
+		return upd.ChatMember.Chat.ID, true
+	case upd.ChatJoinRequest != nil:
+		return upd.ChatJoinRequest.Chat.ID, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,84 @@
+//go:build go1.21
+
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/go-telegram/bot/models"
+)
+
+// WithLogger allows to log errors, debug messages, Bot API requests and processed updates with slog.
+// Records have fields method, chat_id, update_id, update_type, duration and error_code. The bot token is redacted.
+// Requests and updates are logged with debug level, failed requests with warn level and errors with error level.
+// Replaces handlers set by WithErrorsHandler and WithDebugHandler
+func WithLogger(logger *slog.Logger) Option {
+	return func(b *Bot) {
+		l := &slogLogger{logger: logger, bot: b}
+
+		b.structuredLogger = l
+		b.errorsHandler = l.error
+		b.debugHandler = l.debug
+	}
+}
+
+type slogLogger struct {
+	logger *slog.Logger
+	bot    *Bot
+}
+
+func (l *slogLogger) error(err error) {
+	attrs := []slog.Attr{slog.String("error", l.bot.redactToken(err.Error()))}
+	if code := apiErrorCode(err); code != "error" {
+		attrs = append(attrs, slog.String("error_code", code))
+	}
+
+	l.logger.LogAttrs(context.Background(), slog.LevelError, "telegram bot error", attrs...)
+}
+
+func (l *slogLogger) debug(format string, args ...any) {
+	l.logger.LogAttrs(context.Background(), slog.LevelDebug, l.bot.redactToken(fmt.Sprintf(format, args...)))
+}
+
+func (l *slogLogger) logRequest(ctx context.Context, method string, params any, d time.Duration, err error) {
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.Duration("duration", d),
+	}
+	if chatID := paramsChatID(params); chatID != nil {
+		attrs = append(attrs, slog.Any("chat_id", chatID))
+	}
+
+	level := slog.LevelDebug
+	if err != nil {
+		attrs = append(attrs,
+			slog.String("error", l.bot.redactToken(err.Error())),
+			slog.String("error_code", apiErrorCode(err)),
+		)
+		if !errors.Is(err, context.Canceled) {
+			level = slog.LevelWarn
+		}
+	}
+
+	l.logger.LogAttrs(ctx, level, "telegram api request", attrs...)
+}
+
+func (l *slogLogger) logUpdate(ctx context.Context, upd *models.Update, d time.Duration, err error) {
+	attrs := []slog.Attr{
+		slog.Int64("update_id", upd.ID),
+		slog.String("update_type", updateType(upd)),
+		slog.Duration("duration", d),
+	}
+	if chatID, ok := updateChatID(upd); ok {
+		attrs = append(attrs, slog.Int64("chat_id", chatID))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", l.bot.redactToken(err.Error())))
+	}
+
+	l.logger.LogAttrs(ctx, slog.LevelDebug, "telegram update processed", attrs...)
+}
@@ -0,0 +1,53 @@
+//go:build go1.21
+
+package bot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestWithLogger(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	b, err := New("123:SECRET", WithSkipGetMe(), WithHTTPClient(0, errClientMock{}), WithLogger(logger),
+		WithDefaultHandler(func(ctx context.Context, bot *Bot, update *models.Update) {
+			_, _ = bot.SendMessage(ctx, &SendMessageParams{ChatID: update.Message.Chat.ID, Text: "text"})
+		}))
+	assertNoErr(t, err)
+
+	b.processUpdate(context.Background(), &models.Update{ID: 5, Message: &models.Message{Chat: models.Chat{ID: 42}}})
+	b.error("some error, %w", ErrorForbidden)
+
+	assertTrue(t, !strings.Contains(buf.String(), "SECRET"))
+
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		r := map[string]any{}
+		assertNoErr(t, json.Unmarshal([]byte(line), &r))
+		records = append(records, r)
+	}
+
+	assertEqualInt(t, len(records), 3)
+
+	assertEqualString(t, records[0]["msg"].(string), "telegram api request")
+	assertEqualString(t, records[0]["level"].(string), "WARN")
+	assertEqualString(t, records[0]["method"].(string), "sendMessage")
+	assertEqualInt(t, int(records[0]["chat_id"].(float64)), 42)
+	assertEqualString(t, records[0]["error_code"].(string), "error")
+
+	assertEqualString(t, records[1]["msg"].(string), "telegram update processed")
+	assertEqualInt(t, int(records[1]["update_id"].(float64)), 5)
+	assertEqualInt(t, int(records[1]["chat_id"].(float64)), 42)
+	assertEqualString(t, records[1]["update_type"].(string), "message")
+
+	assertEqualString(t, records[2]["level"].(string), "ERROR")
+	assertEqualString(t, records[2]["error_code"].(string), "403")
+}
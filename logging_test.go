@@ -0,0 +1,62 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-telegram/bot/models"
+)
+
+type errClientMock struct{}
+
+func (errClientMock) Do(req *http.Request) (*http.Response, error) {
+	return nil, &url.Error{Op: "Post", URL: req.URL.String(), Err: errors.New("connection refused")}
+}
+
+func TestBot_RedactTokenInURLError(t *testing.T) {
+	var debugMessages []string
+
+	b, err := New("123:SECRET", WithSkipGetMe(), WithHTTPClient(0, errClientMock{}), WithDebug(),
+		WithDebugHandler(func(format string, args ...any) {
+			debugMessages = append(debugMessages, fmt.Sprintf(format, args...))
+		}))
+	assertNoErr(t, err)
+
+	_, err = b.SendMessage(context.Background(), &SendMessageParams{ChatID: 1, Text: "text"})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	assertTrue(t, !strings.Contains(err.Error(), "SECRET"))
+	assertTrue(t, strings.Contains(err.Error(), "/bot<token>/sendMessage"))
+
+	assertEqualInt(t, len(debugMessages), 1)
+	assertTrue(t, !strings.Contains(debugMessages[0], "SECRET"))
+}
+
+func TestParamsChatID(t *testing.T) {
+	assertTrue(t, paramsChatID(&SendMessageParams{ChatID: "@channel"}) == "@channel")
+	assertTrue(t, paramsChatID(&SendMessageParams{}) == nil)
+	assertTrue(t, paramsChatID(&GetFileParams{FileID: "1"}) == nil)
+	assertTrue(t, paramsChatID(nil) == nil)
+}
+
+func TestUpdateChatID(t *testing.T) {
+	id, ok := updateChatID(&models.Update{Message: &models.Message{Chat: models.Chat{ID: 1}}})
+	assertTrue(t, ok)
+	assertEqualInt(t, int(id), 1)
+
+	id, ok = updateChatID(&models.Update{CallbackQuery: &models.CallbackQuery{Message: models.MaybeInaccessibleMessage{
+		InaccessibleMessage: &models.InaccessibleMessage{Chat: models.Chat{ID: 2}},
+	}}})
+	assertTrue(t, ok)
+	assertEqualInt(t, int(id), 2)
+
+	_, ok = updateChatID(&models.Update{InlineQuery: &models.InlineQuery{}})
+	assertTrue(t, !ok)
+}
@@ -0,0 +1,238 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"sync"
+
+	"github.com/go-telegram/bot/models"
+)
+
+// Manager hosts many bots in one process with one webhook endpoint and a shared worker pool.
+// Each bot keeps its own handlers, middlewares and options.
+// Updates of bots with WithDiskQueue are written to the bot disk queue first, then passed to the shared workers.
+// Bots with WithSyncWebhook process updates in the webhook request
+type Manager struct {
+	mx       sync.RWMutex
+	bots     map[string]*managedBot
+	bySecret map[string]*managedBot
+
+	workers int
+	tasks   chan managerTask
+
+	// ctx is set by Start, feeders of disk queues are started with it
+	ctx     context.Context
+	feeders sync.WaitGroup
+}
+
+type managedBot struct {
+	key     string
+	bot     *Bot
+	handler http.HandlerFunc
+	// stopFeeder stops passing updates from the bot disk queue to the shared workers
+	stopFeeder context.CancelFunc
+}
+
+type managerTask struct {
+	bot    *Bot
+	update *models.Update
+}
+
+// NewManager returns new Manager with the number of workers shared by all bots
+// and the capacity of the shared updates queue
+func NewManager(workers, queueCap int) *Manager {
+	if workers < 1 {
+		workers = defaultWorkers
+	}
+
+	return &Manager{
+		bots:     map[string]*managedBot{},
+		bySecret: map[string]*managedBot{},
+		workers:  workers,
+		tasks:    make(chan managerTask, queueCap),
+	}
+}
+
+// AddBot adds the bot with the key. Webhook requests are routed to the bot by the last path element equal to the key,
+// or by the bot webhook secret token, see WithWebhookSecretToken
+func (m *Manager) AddBot(key string, b *Bot) error {
+	if key == "" {
+		return fmt.Errorf("empty bot key")
+	}
+
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	if _, ok := m.bots[key]; ok {
+		return fmt.Errorf("bot with key %s already exists", key)
+	}
+	if b.webhookSecretToken != "" {
+		if _, ok := m.bySecret[b.webhookSecretToken]; ok {
+			return fmt.Errorf("bot with the same webhook secret token already exists")
+		}
+	}
+
+	mb := &managedBot{key: key, bot: b}
+	mb.handler = b.webhookHandler(func(update *models.Update) bool {
+		return m.enqueue(b, update)
+	})
+
+	m.bots[key] = mb
+	if b.webhookSecretToken != "" {
+		m.bySecret[b.webhookSecretToken] = mb
+	}
+
+	if m.ctx != nil {
+		m.startFeeder(mb)
+	}
+
+	return nil
+}
+
+// RemoveBot removes the bot by the key. Updates of the bot already in the queue are still processed
+func (m *Manager) RemoveBot(key string) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	mb, ok := m.bots[key]
+	if !ok {
+		return
+	}
+
+	delete(m.bots, key)
+	if mb.bot.webhookSecretToken != "" {
+		delete(m.bySecret, mb.bot.webhookSecretToken)
+	}
+	if mb.stopFeeder != nil {
+		mb.stopFeeder()
+	}
+}
+
+// Bot returns the bot by the key
+func (m *Manager) Bot(key string) (*Bot, bool) {
+	m.mx.RLock()
+	defer m.mx.RUnlock()
+
+	mb, ok := m.bots[key]
+	if !ok {
+		return nil, false
+	}
+	return mb.bot, true
+}
+
+// Keys returns keys of all bots
+func (m *Manager) Keys() []string {
+	m.mx.RLock()
+	defer m.mx.RUnlock()
+
+	keys := make([]string, 0, len(m.bots))
+	for key := range m.bots {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// WebhookHandler returns http handler which routes webhook requests to the bots.
+// Responds with 404 if no bot is found for the request
+func (m *Manager) WebhookHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		mb := m.route(req)
+		if mb == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		mb.handler(w, req)
+	}
+}
+
+func (m *Manager) route(req *http.Request) *managedBot {
+	m.mx.RLock()
+	defer m.mx.RUnlock()
+
+	if secret := req.Header.Get("X-Telegram-Bot-Api-Secret-Token"); secret != "" {
+		if mb, ok := m.bySecret[secret]; ok {
+			return mb
+		}
+	}
+
+	return m.bots[path.Base(req.URL.Path)]
+}
+
+// enqueue passes the update to the shared workers. Updates of bots with the disk queue are written to the queue,
+// the feeder of the queue passes them to the workers
+func (m *Manager) enqueue(b *Bot, update *models.Update) bool {
+	if b.diskQueue != nil {
+		return b.enqueueUpdate(update)
+	}
+
+	select {
+	case m.tasks <- managerTask{bot: b, update: update}:
+		return true
+	default:
+		return false
+	}
+}
+
+// Start starts the shared workers, which process updates of all bots, and blocks until ctx is done
+func (m *Manager) Start(ctx context.Context) {
+	wg := sync.WaitGroup{}
+
+	m.mx.Lock()
+	m.ctx = ctx
+	for _, mb := range m.bots {
+		m.startFeeder(mb)
+	}
+	m.mx.Unlock()
+
+	wg.Add(m.workers)
+	for i := 0; i < m.workers; i++ {
+		go m.waitTasks(ctx, &wg)
+	}
+
+	wg.Wait()
+	m.feeders.Wait()
+}
+
+// startFeeder starts passing updates from the bot disk queue to the shared workers, m.mx must be locked
+func (m *Manager) startFeeder(mb *managedBot) {
+	if mb.bot.diskQueue == nil || mb.stopFeeder != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(m.ctx)
+	mb.stopFeeder = cancel
+
+	m.feeders.Add(1)
+	go func() {
+		defer m.feeders.Done()
+
+		for {
+			upd, ok := mb.bot.diskQueue.next(ctx)
+			if !ok {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case m.tasks <- managerTask{bot: mb.bot, update: upd}:
+			}
+		}
+	}()
+}
+
+func (m *Manager) waitTasks(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case task := <-m.tasks:
+			task.bot.processUpdate(ctx, task.update)
+		}
+	}
+}
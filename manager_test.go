@@ -0,0 +1,137 @@
+package bot
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func newManagerTestBot(t *testing.T, secret string, updates chan string, name string) *Bot {
+	opts := []Option{
+		WithSkipGetMe(),
+		WithDefaultHandler(func(_ context.Context, _ *Bot, update *models.Update) {
+			updates <- name
+		}),
+	}
+	if secret != "" {
+		opts = append(opts, WithWebhookSecretToken(secret))
+	}
+	b, err := New("XXX", opts...)
+	assertNoErr(t, err)
+	return b
+}
+
+func TestManager(t *testing.T) {
+	updates := make(chan string, 10)
+
+	m := NewManager(2, 10)
+	assertNoErr(t, m.AddBot("foo", newManagerTestBot(t, "", updates, "foo")))
+	assertNoErr(t, m.AddBot("bar", newManagerTestBot(t, "bar-secret", updates, "bar")))
+
+	if err := m.AddBot("foo", newManagerTestBot(t, "", updates, "foo")); err == nil {
+		t.Fatal("expected error for duplicated key")
+	}
+	if err := m.AddBot("baz", newManagerTestBot(t, "bar-secret", updates, "baz")); err == nil {
+		t.Fatal("expected error for duplicated secret token")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go m.Start(ctx)
+
+	send := func(path, secret string) int {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"update_id":1}`))
+		if secret != "" {
+			req.Header.Set("X-Telegram-Bot-Api-Secret-Token", secret)
+		}
+		w := httptest.NewRecorder()
+		m.WebhookHandler()(w, req)
+		return w.Code
+	}
+
+	expectUpdate := func(name string) {
+		select {
+		case got := <-updates:
+			assertEqualString(t, got, name)
+		case <-time.After(time.Second):
+			t.Fatalf("update for %s is not processed", name)
+		}
+	}
+
+	assertEqualInt(t, send("/webhook/foo", ""), http.StatusOK)
+	expectUpdate("foo")
+
+	assertEqualInt(t, send("/webhook", "bar-secret"), http.StatusOK)
+	expectUpdate("bar")
+
+	assertEqualInt(t, send("/webhook/bar", "wrong"), http.StatusUnauthorized)
+	assertEqualInt(t, send("/webhook/unknown", ""), http.StatusNotFound)
+
+	m.RemoveBot("foo")
+	assertEqualInt(t, send("/webhook/foo", ""), http.StatusNotFound)
+
+	_, ok := m.Bot("foo")
+	assertTrue(t, !ok)
+	assertEqualInt(t, len(m.Keys()), 1)
+}
+
+func TestManager_QueueFull(t *testing.T) {
+	m := NewManager(1, 1)
+	assertNoErr(t, m.AddBot("foo", newManagerTestBot(t, "", make(chan string, 10), "foo")))
+
+	send := func() int {
+		req := httptest.NewRequest(http.MethodPost, "/foo", strings.NewReader(`{"update_id":1}`))
+		w := httptest.NewRecorder()
+		m.WebhookHandler()(w, req)
+		return w.Code
+	}
+
+	assertEqualInt(t, send(), http.StatusOK)
+	assertEqualInt(t, send(), http.StatusServiceUnavailable)
+}
+
+func TestManager_DiskQueue(t *testing.T) {
+	q, err := OpenDiskQueue(filepath.Join(t.TempDir(), "updates.wal"))
+	assertNoErr(t, err)
+	defer q.Close()
+
+	processed := make(chan int64, 1)
+	b, err := New("XXX", WithSkipGetMe(), WithDiskQueue(q), WithDefaultHandler(func(_ context.Context, _ *Bot, update *models.Update) {
+		processed <- update.ID
+	}))
+	assertNoErr(t, err)
+
+	m := NewManager(1, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go m.Start(ctx)
+
+	// the bot is added after the manager is started
+	time.Sleep(time.Millisecond * 10)
+	assertNoErr(t, m.AddBot("foo", b))
+
+	w := httptest.NewRecorder()
+	m.WebhookHandler()(w, httptest.NewRequest(http.MethodPost, "/foo", strings.NewReader(`{"update_id":42}`)))
+	assertEqualInt(t, w.Code, http.StatusOK)
+
+	select {
+	case id := <-processed:
+		assertEqualInt(t, int(id), 42)
+	case <-time.After(time.Second):
+		t.Fatal("update is not processed")
+	}
+
+	for i := 0; i < 100 && q.Len() > 0; i++ {
+		time.Sleep(time.Millisecond * 10)
+	}
+	assertEqualInt(t, q.Len(), 0)
+}
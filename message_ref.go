@@ -0,0 +1,130 @@
+package bot
+
+import (
+	"github.com/go-telegram/bot/models"
+)
+
+// MessageRef refers to a message for edit and delete methods: a message in a chat or an inline message
+type MessageRef struct {
+	ChatID    models.ChatID
+	MessageID int
+	// InlineMessageID is set for inline messages instead of ChatID and MessageID
+	InlineMessageID string
+	// BusinessConnectionID is set for messages sent on behalf of a business account
+	BusinessConnectionID string
+}
+
+// NewMessageRef returns MessageRef to the message in the chat
+func NewMessageRef(chatID models.ChatID, messageID int) MessageRef {
+	return MessageRef{ChatID: chatID, MessageID: messageID}
+}
+
+// NewInlineMessageRef returns MessageRef to the inline message
+func NewInlineMessageRef(inlineMessageID string) MessageRef {
+	return MessageRef{InlineMessageID: inlineMessageID}
+}
+
+// MessageRefFromMessage returns MessageRef to the message
+func MessageRefFromMessage(m *models.Message) MessageRef {
+	if m == nil {
+		return MessageRef{}
+	}
+
+	return MessageRef{
+		ChatID:               models.NewChatID(m.Chat.ID),
+		MessageID:            m.ID,
+		BusinessConnectionID: m.BusinessConnectionID,
+	}
+}
+
+// MessageRefFromCallbackQuery returns MessageRef to the message with the callback button,
+// the inline message or the message, which is no longer accessible
+func MessageRefFromCallbackQuery(q *models.CallbackQuery) MessageRef {
+	switch {
+	case q == nil:
+		return MessageRef{}
+	case q.InlineMessageID != "":
+		return NewInlineMessageRef(q.InlineMessageID)
+	case q.Message.Message != nil:
+		return MessageRefFromMessage(q.Message.Message)
+	case q.Message.InaccessibleMessage != nil:
+		return NewMessageRef(models.NewChatID(q.Message.InaccessibleMessage.Chat.ID), q.Message.InaccessibleMessage.MessageID)
+	}
+
+	return MessageRef{}
+}
+
+// MessageRefFromChosenInlineResult returns MessageRef to the sent inline message.
+// The ref is empty if the message has no inline keyboard, Telegram sends inline_message_id only in this case
+func MessageRefFromChosenInlineResult(r *models.ChosenInlineResult) MessageRef {
+	if r == nil {
+		return MessageRef{}
+	}
+
+	return NewInlineMessageRef(r.InlineMessageID)
+}
+
+// IsInline reports whether the ref is to an inline message
+func (r MessageRef) IsInline() bool {
+	return r.InlineMessageID != ""
+}
+
+// IsZero reports whether the ref is empty
+func (r MessageRef) IsZero() bool {
+	return r.InlineMessageID == "" && r.ChatID.IsZero() && r.MessageID == 0
+}
+
+// MessageRefParams are params of edit and delete methods, which accept MessageRef, see WithMessageRef
+type MessageRefParams interface {
+	setMessageRef(ref MessageRef)
+}
+
+// WithMessageRef sets the message fields of params from the ref and returns params:
+//
+//	b.EditMessageText(ctx, bot.WithMessageRef(ref, &bot.EditMessageTextParams{Text: "new text"}))
+//
+// Methods without inline_message_id, like DeleteMessage and StopPoll, get only ChatID and MessageID from the ref
+func WithMessageRef[P MessageRefParams](ref MessageRef, params P) P {
+	params.setMessageRef(ref)
+	return params
+}
+
+func (p *EditMessageTextParams) setMessageRef(ref MessageRef) {
+	p.Chat, p.ChatID, p.MessageID, p.InlineMessageID, p.BusinessConnectionID = ref.ChatID, nil, ref.MessageID, ref.InlineMessageID, ref.BusinessConnectionID
+}
+
+func (p *EditMessageCaptionParams) setMessageRef(ref MessageRef) {
+	p.Chat, p.ChatID, p.MessageID, p.InlineMessageID, p.BusinessConnectionID = ref.ChatID, nil, ref.MessageID, ref.InlineMessageID, ref.BusinessConnectionID
+}
+
+func (p *EditMessageMediaParams) setMessageRef(ref MessageRef) {
+	p.Chat, p.ChatID, p.MessageID, p.InlineMessageID, p.BusinessConnectionID = ref.ChatID, nil, ref.MessageID, ref.InlineMessageID, ref.BusinessConnectionID
+}
+
+func (p *EditMessageReplyMarkupParams) setMessageRef(ref MessageRef) {
+	p.Chat, p.ChatID, p.MessageID, p.InlineMessageID, p.BusinessConnectionID = ref.ChatID, nil, ref.MessageID, ref.InlineMessageID, ref.BusinessConnectionID
+}
+
+func (p *EditMessageLiveLocationParams) setMessageRef(ref MessageRef) {
+	p.Chat, p.ChatID, p.MessageID, p.InlineMessageID, p.BusinessConnectionID = ref.ChatID, nil, ref.MessageID, ref.InlineMessageID, ref.BusinessConnectionID
+}
+
+func (p *StopMessageLiveLocationParams) setMessageRef(ref MessageRef) {
+	p.Chat, p.ChatID, p.MessageID, p.InlineMessageID, p.BusinessConnectionID = ref.ChatID, nil, ref.MessageID, ref.InlineMessageID, ref.BusinessConnectionID
+}
+
+func (p *SetGameScoreParams) setMessageRef(ref MessageRef) {
+	p.Chat, p.ChatID, p.MessageID, p.InlineMessageID = ref.ChatID, nil, ref.MessageID, ref.InlineMessageID
+}
+
+func (p *GetGameHighScoresParams) setMessageRef(ref MessageRef) {
+	p.Chat, p.ChatID, p.MessageID, p.InlineMessageID = ref.ChatID, nil, ref.MessageID, ref.InlineMessageID
+}
+
+func (p *StopPollParams) setMessageRef(ref MessageRef) {
+	p.Chat, p.ChatID, p.MessageID, p.BusinessConnectionID = ref.ChatID, nil, ref.MessageID, ref.BusinessConnectionID
+}
+
+func (p *DeleteMessageParams) setMessageRef(ref MessageRef) {
+	p.Chat, p.ChatID, p.MessageID = ref.ChatID, nil, ref.MessageID
+}
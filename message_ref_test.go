@@ -0,0 +1,71 @@
+package bot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestMessageRefFrom(t *testing.T) {
+	message := &models.Message{ID: 2, Chat: models.Chat{ID: 1}, BusinessConnectionID: "bc"}
+
+	ref := MessageRefFromMessage(message)
+	assertTrue(t, ref == MessageRef{ChatID: models.NewChatID(1), MessageID: 2, BusinessConnectionID: "bc"})
+	assertTrue(t, !ref.IsInline())
+
+	ref = MessageRefFromCallbackQuery(&models.CallbackQuery{Message: models.MaybeInaccessibleMessage{Message: message}})
+	assertTrue(t, ref == MessageRef{ChatID: models.NewChatID(1), MessageID: 2, BusinessConnectionID: "bc"})
+
+	ref = MessageRefFromCallbackQuery(&models.CallbackQuery{Message: models.MaybeInaccessibleMessage{
+		InaccessibleMessage: &models.InaccessibleMessage{Chat: models.Chat{ID: 3}, MessageID: 4},
+	}})
+	assertTrue(t, ref == NewMessageRef(models.NewChatID(3), 4))
+
+	ref = MessageRefFromCallbackQuery(&models.CallbackQuery{InlineMessageID: "inline"})
+	assertTrue(t, ref == NewInlineMessageRef("inline"))
+	assertTrue(t, ref.IsInline())
+
+	ref = MessageRefFromChosenInlineResult(&models.ChosenInlineResult{InlineMessageID: "inline"})
+	assertTrue(t, ref == NewInlineMessageRef("inline"))
+
+	assertTrue(t, MessageRefFromChosenInlineResult(&models.ChosenInlineResult{}).IsZero())
+	assertTrue(t, MessageRefFromCallbackQuery(nil).IsZero())
+}
+
+func TestWithMessageRef(t *testing.T) {
+	ctx := context.Background()
+
+	client := &httpClient{t: t, resp: `true`, reqFields: map[string]string{
+		"inline_message_id": "inline",
+		"text":              "text",
+	}}
+	b, err := New("xxx", WithSkipGetMe(), WithHTTPClient(0, client), WithParamsValidation())
+	assertNoErr(t, err)
+
+	ref := NewInlineMessageRef("inline")
+
+	inlineMsg, err := b.EditMessageText(ctx, WithMessageRef(ref, &EditMessageTextParams{Text: "text"}))
+	assertNoErr(t, err)
+	assertTrue(t, inlineMsg == nil)
+
+	client.resp = `{"message_id":2,"chat":{"id":1}}`
+	client.reqFields = map[string]string{
+		"chat_id":    "@channel",
+		"message_id": "2",
+	}
+
+	ref = NewMessageRef(models.NewChatUsername("channel"), 2)
+
+	msg, err := b.EditMessageReplyMarkup(ctx, WithMessageRef(ref, &EditMessageReplyMarkupParams{}))
+	assertNoErr(t, err)
+	assertEqualInt(t, msg.ID, 2)
+
+	client.resp = `true`
+	_, err = b.DeleteMessage(ctx, WithMessageRef(ref, &DeleteMessageParams{}))
+	assertNoErr(t, err)
+
+	params := WithMessageRef(NewInlineMessageRef("inline"), &SetGameScoreParams{UserID: 1, Score: 2})
+	assertTrue(t, params.ChatID == nil && params.Chat.IsZero())
+	assertEqualString(t, params.InlineMessageID, "inline")
+}
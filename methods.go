@@ -1,3 +1,5 @@
+// Code generated by apigen from Bot API 8.2. DO NOT EDIT.
+
 package bot
 
 import (
@@ -16,7 +18,7 @@ func (b *Bot) SetWebhook(ctx context.Context, params *SetWebhookParams) (bool, e
 // GetWebhookInfo https://core.telegram.org/bots/api#getwebhookinfo
 func (b *Bot) GetWebhookInfo(ctx context.Context) (*models.WebhookInfo, error) {
 	result := &models.WebhookInfo{}
-	err := b.rawRequest(ctx, "getWebhookInfo", nil, &result)
+	err := b.rawRequest(ctx, "getWebhookInfo", nil, result)
 	return result, err
 }
 
@@ -37,7 +39,7 @@ func (b *Bot) GetMe(ctx context.Context) (*models.User, error) {
 // Logout https://core.telegram.org/bots/api#logout
 func (b *Bot) Logout(ctx context.Context) (bool, error) {
 	var result bool
-	err := b.rawRequest(ctx, "logout", nil, &result)
+	err := b.rawRequest(ctx, "logOut", nil, &result)
 	return result, err
 }
 
@@ -50,9 +52,9 @@ func (b *Bot) Close(ctx context.Context) (bool, error) {
 
 // SendMessage https://core.telegram.org/bots/api#sendmessage
 func (b *Bot) SendMessage(ctx context.Context, params *SendMessageParams) (*models.Message, error) {
-	mes := &models.Message{}
-	err := b.rawRequest(ctx, "sendMessage", params, mes)
-	return mes, err
+	result := &models.Message{}
+	err := b.rawRequest(ctx, "sendMessage", params, result)
+	return result, err
 }
 
 // ForwardMessage https://core.telegram.org/bots/api#forwardmessage
@@ -85,9 +87,9 @@ func (b *Bot) CopyMessages(ctx context.Context, params *CopyMessagesParams) ([]m
 
 // SendPhoto https://core.telegram.org/bots/api#sendphoto
 func (b *Bot) SendPhoto(ctx context.Context, params *SendPhotoParams) (*models.Message, error) {
-	mes := &models.Message{}
-	err := b.rawRequest(ctx, "sendPhoto", params, mes)
-	return mes, err
+	result := &models.Message{}
+	err := b.rawRequest(ctx, "sendPhoto", params, result)
+	return result, err
 }
 
 // SendAudio https://core.telegram.org/bots/api#sendaudio
@@ -134,9 +136,9 @@ func (b *Bot) SendVideoNote(ctx context.Context, params *SendVideoNoteParams) (*
 
 // SendPaidMedia https://core.telegram.org/bots/api#sendpaidmedia
 func (b *Bot) SendPaidMedia(ctx context.Context, params *SendPaidMediaParams) (*models.Message, error) {
-	var result models.Message
-	err := b.rawRequest(ctx, "sendPaidMedia", params, &result)
-	return &result, err
+	result := &models.Message{}
+	err := b.rawRequest(ctx, "sendPaidMedia", params, result)
+	return result, err
 }
 
 // SendMediaGroup https://core.telegram.org/bots/api#sendmediagroup
@@ -154,17 +156,21 @@ func (b *Bot) SendLocation(ctx context.Context, params *SendLocationParams) (*mo
 }
 
 // EditMessageLiveLocation https://core.telegram.org/bots/api#editmessagelivelocation
+//
+// The message is nil for inline messages
 func (b *Bot) EditMessageLiveLocation(ctx context.Context, params *EditMessageLiveLocationParams) (*models.Message, error) {
-	result := &models.Message{}
+	result := &messageOrTrue{}
 	err := b.rawRequest(ctx, "editMessageLiveLocation", params, result)
-	return result, err
+	return result.message, err
 }
 
 // StopMessageLiveLocation https://core.telegram.org/bots/api#stopmessagelivelocation
+//
+// The message is nil for inline messages
 func (b *Bot) StopMessageLiveLocation(ctx context.Context, params *StopMessageLiveLocationParams) (*models.Message, error) {
-	result := &models.Message{}
+	result := &messageOrTrue{}
 	err := b.rawRequest(ctx, "stopMessageLiveLocation", params, result)
-	return result, err
+	return result.message, err
 }
 
 // SendVenue https://core.telegram.org/bots/api#sendvenue
@@ -191,7 +197,7 @@ func (b *Bot) SendPoll(ctx context.Context, params *SendPollParams) (*models.Mes
 // SendDice https://core.telegram.org/bots/api#senddice
 func (b *Bot) SendDice(ctx context.Context, params *SendDiceParams) (*models.Message, error) {
 	result := &models.Message{}
-	err := b.rawRequest(ctx, "sendDice", params, &result)
+	err := b.rawRequest(ctx, "sendDice", params, result)
 	return result, err
 }
 
@@ -296,35 +302,35 @@ func (b *Bot) ExportChatInviteLink(ctx context.Context, params *ExportChatInvite
 // CreateChatInviteLink https://core.telegram.org/bots/api#createchatinvitelink
 func (b *Bot) CreateChatInviteLink(ctx context.Context, params *CreateChatInviteLinkParams) (*models.ChatInviteLink, error) {
 	result := &models.ChatInviteLink{}
-	err := b.rawRequest(ctx, "createChatInviteLink", params, &result)
+	err := b.rawRequest(ctx, "createChatInviteLink", params, result)
 	return result, err
 }
 
 // EditChatInviteLink https://core.telegram.org/bots/api#editchatinvitelink
 func (b *Bot) EditChatInviteLink(ctx context.Context, params *EditChatInviteLinkParams) (*models.ChatInviteLink, error) {
 	result := &models.ChatInviteLink{}
-	err := b.rawRequest(ctx, "editChatInviteLink", params, &result)
+	err := b.rawRequest(ctx, "editChatInviteLink", params, result)
 	return result, err
 }
 
 // CreateChatSubscriptionInviteLink https://core.telegram.org/bots/api#createchatsubscriptioninvitelink
 func (b *Bot) CreateChatSubscriptionInviteLink(ctx context.Context, params *CreateChatSubscriptionInviteLinkParams) (*models.ChatInviteLink, error) {
 	result := &models.ChatInviteLink{}
-	err := b.rawRequest(ctx, "createChatSubscriptionInviteLink", params, &result)
+	err := b.rawRequest(ctx, "createChatSubscriptionInviteLink", params, result)
 	return result, err
 }
 
 // EditChatSubscriptionInviteLink https://core.telegram.org/bots/api#editchatsubscriptioninvitelink
 func (b *Bot) EditChatSubscriptionInviteLink(ctx context.Context, params *EditChatSubscriptionInviteLinkParams) (*models.ChatInviteLink, error) {
 	result := &models.ChatInviteLink{}
-	err := b.rawRequest(ctx, "editChatSubscriptionInviteLink", params, &result)
+	err := b.rawRequest(ctx, "editChatSubscriptionInviteLink", params, result)
 	return result, err
 }
 
 // RevokeChatInviteLink https://core.telegram.org/bots/api#revokechatinvitelink
 func (b *Bot) RevokeChatInviteLink(ctx context.Context, params *RevokeChatInviteLinkParams) (*models.ChatInviteLink, error) {
 	result := &models.ChatInviteLink{}
-	err := b.rawRequest(ctx, "revokeChatInviteLink", params, &result)
+	err := b.rawRequest(ctx, "revokeChatInviteLink", params, result)
 	return result, err
 }
 
@@ -400,8 +406,8 @@ func (b *Bot) LeaveChat(ctx context.Context, params *LeaveChatParams) (bool, err
 
 // GetChat https://core.telegram.org/bots/api#getchat
 func (b *Bot) GetChat(ctx context.Context, params *GetChatParams) (*models.ChatFullInfo, error) {
-	var result *models.ChatFullInfo
-	err := b.rawRequest(ctx, "getChat", params, &result)
+	result := &models.ChatFullInfo{}
+	err := b.rawRequest(ctx, "getChat", params, result)
 	return result, err
 }
 
@@ -422,7 +428,7 @@ func (b *Bot) GetChatMemberCount(ctx context.Context, params *GetChatMemberCount
 // GetChatMember https://core.telegram.org/bots/api#getchatmember
 func (b *Bot) GetChatMember(ctx context.Context, params *GetChatMemberParams) (*models.ChatMember, error) {
 	result := &models.ChatMember{}
-	err := b.rawRequest(ctx, "getChatMember", params, &result)
+	err := b.rawRequest(ctx, "getChatMember", params, result)
 	return result, err
 }
 
@@ -443,7 +449,7 @@ func (b *Bot) GetForumTopicIconStickers(ctx context.Context) ([]*models.Sticker,
 // CreateForumTopic https://core.telegram.org/bots/api#createforumtopic
 func (b *Bot) CreateForumTopic(ctx context.Context, params *CreateForumTopicParams) (*models.ForumTopic, error) {
 	result := &models.ForumTopic{}
-	err := b.rawRequest(ctx, "createForumTopic", params, &result)
+	err := b.rawRequest(ctx, "createForumTopic", params, result)
 	return result, err
 }
 
@@ -468,7 +474,7 @@ func (b *Bot) ReopenForumTopic(ctx context.Context, params *ReopenForumTopicPara
 	return result, err
 }
 
-// UnpinAllForumTopicMessages https://core.telegram.org/bots/api#deleteforumtopic
+// UnpinAllForumTopicMessages https://core.telegram.org/bots/api#unpinallforumtopicmessages
 func (b *Bot) UnpinAllForumTopicMessages(ctx context.Context, params *UnpinAllForumTopicMessagesParams) (bool, error) {
 	var result bool
 	err := b.rawRequest(ctx, "unpinAllForumTopicMessages", params, &result)
@@ -517,7 +523,7 @@ func (b *Bot) UnpinAllGeneralForumTopicMessages(ctx context.Context, params *Unp
 	return result, err
 }
 
-// DeleteForumTopic https://core.telegram.org/bots/api#unpinallforumtopicmessages
+// DeleteForumTopic https://core.telegram.org/bots/api#deleteforumtopic
 func (b *Bot) DeleteForumTopic(ctx context.Context, params *DeleteForumTopicParams) (bool, error) {
 	var result bool
 	err := b.rawRequest(ctx, "deleteForumTopic", params, &result)
@@ -541,14 +547,14 @@ func (b *Bot) AnswerCallbackQuery(ctx context.Context, params *AnswerCallbackQue
 // GetUserChatBoosts https://core.telegram.org/bots/api#getuserchatboosts
 func (b *Bot) GetUserChatBoosts(ctx context.Context, params *GetUserChatBoostsParams) (*models.UserChatBoosts, error) {
 	result := &models.UserChatBoosts{}
-	err := b.rawRequest(ctx, "getUserChatBoosts", params, &result)
+	err := b.rawRequest(ctx, "getUserChatBoosts", params, result)
 	return result, err
 }
 
 // GetBusinessConnection https://core.telegram.org/bots/api#getbusinessconnection
 func (b *Bot) GetBusinessConnection(ctx context.Context, params *GetBusinessConnectionParams) (*models.BusinessConnection, error) {
 	result := &models.BusinessConnection{}
-	err := b.rawRequest(ctx, "getBusinessConnection", params, &result)
+	err := b.rawRequest(ctx, "getBusinessConnection", params, result)
 	return result, err
 }
 
@@ -639,42 +645,50 @@ func (b *Bot) SetMyDefaultAdministratorRights(ctx context.Context, params *SetMy
 // GetMyDefaultAdministratorRights https://core.telegram.org/bots/api#getmydefaultadministratorrights
 func (b *Bot) GetMyDefaultAdministratorRights(ctx context.Context, params *GetMyDefaultAdministratorRightsParams) (*models.ChatAdministratorRights, error) {
 	result := &models.ChatAdministratorRights{}
-	err := b.rawRequest(ctx, "setMyDefaultAdministratorRights", params, &result)
+	err := b.rawRequest(ctx, "getMyDefaultAdministratorRights", params, result)
 	return result, err
 }
 
 // EditMessageText https://core.telegram.org/bots/api#editmessagetext
+//
+// The message is nil for inline messages
 func (b *Bot) EditMessageText(ctx context.Context, params *EditMessageTextParams) (*models.Message, error) {
-	result := &models.Message{}
+	result := &messageOrTrue{}
 	err := b.rawRequest(ctx, "editMessageText", params, result)
-	return result, err
+	return result.message, err
 }
 
 // EditMessageCaption https://core.telegram.org/bots/api#editmessagecaption
+//
+// The message is nil for inline messages
 func (b *Bot) EditMessageCaption(ctx context.Context, params *EditMessageCaptionParams) (*models.Message, error) {
-	result := &models.Message{}
+	result := &messageOrTrue{}
 	err := b.rawRequest(ctx, "editMessageCaption", params, result)
-	return result, err
+	return result.message, err
 }
 
 // EditMessageMedia https://core.telegram.org/bots/api#editmessagemedia
+//
+// The message is nil for inline messages
 func (b *Bot) EditMessageMedia(ctx context.Context, params *EditMessageMediaParams) (*models.Message, error) {
-	result := &models.Message{}
+	result := &messageOrTrue{}
 	err := b.rawRequest(ctx, "editMessageMedia", params, result)
-	return result, err
+	return result.message, err
 }
 
 // EditMessageReplyMarkup https://core.telegram.org/bots/api#editmessagereplymarkup
+//
+// The message is nil for inline messages
 func (b *Bot) EditMessageReplyMarkup(ctx context.Context, params *EditMessageReplyMarkupParams) (*models.Message, error) {
-	result := &models.Message{}
+	result := &messageOrTrue{}
 	err := b.rawRequest(ctx, "editMessageReplyMarkup", params, result)
-	return result, err
+	return result.message, err
 }
 
 // StopPoll https://core.telegram.org/bots/api#stoppoll
 func (b *Bot) StopPoll(ctx context.Context, params *StopPollParams) (*models.Poll, error) {
 	result := &models.Poll{}
-	err := b.rawRequest(ctx, "stopPoll", params, &result)
+	err := b.rawRequest(ctx, "stopPoll", params, result)
 	return result, err
 }
 
@@ -695,14 +709,14 @@ func (b *Bot) DeleteMessages(ctx context.Context, params *DeleteMessagesParams)
 // SendSticker https://core.telegram.org/bots/api#sendsticker
 func (b *Bot) SendSticker(ctx context.Context, params *SendStickerParams) (*models.Message, error) {
 	result := &models.Message{}
-	err := b.rawRequest(ctx, "sendSticker", params, &result)
+	err := b.rawRequest(ctx, "sendSticker", params, result)
 	return result, err
 }
 
 // GetStickerSet https://core.telegram.org/bots/api#getstickerset
 func (b *Bot) GetStickerSet(ctx context.Context, params *GetStickerSetParams) (*models.StickerSet, error) {
 	result := &models.StickerSet{}
-	err := b.rawRequest(ctx, "getStickerSet", params, &result)
+	err := b.rawRequest(ctx, "getStickerSet", params, result)
 	return result, err
 }
 
@@ -716,7 +730,7 @@ func (b *Bot) GetCustomEmojiStickers(ctx context.Context, params *GetCustomEmoji
 // UploadStickerFile https://core.telegram.org/bots/api#uploadstickerfile
 func (b *Bot) UploadStickerFile(ctx context.Context, params *UploadStickerFileParams) (*models.File, error) {
 	result := &models.File{}
-	err := b.rawRequest(ctx, "uploadStickerFile", params, &result)
+	err := b.rawRequest(ctx, "uploadStickerFile", params, result)
 	return result, err
 }
 
@@ -776,7 +790,7 @@ func (b *Bot) SetStickerMaskPosition(ctx context.Context, params *SetStickerMask
 	return result, err
 }
 
-// SetStickerSetTitle https://core.telegram.org/bots/api#setstickermaskposition
+// SetStickerSetTitle https://core.telegram.org/bots/api#setstickersettitle
 func (b *Bot) SetStickerSetTitle(ctx context.Context, params *SetStickerSetTitleParams) (bool, error) {
 	var result bool
 	err := b.rawRequest(ctx, "setStickerSetTitle", params, &result)
@@ -855,9 +869,9 @@ func (b *Bot) AnswerPreCheckoutQuery(ctx context.Context, params *AnswerPreCheck
 
 // GetStarTransactions https://core.telegram.org/bots/api#getstartransactions
 func (b *Bot) GetStarTransactions(ctx context.Context, params *GetStarTransactionsParams) (*models.StarTransactions, error) {
-	result := models.StarTransactions{}
-	err := b.rawRequest(ctx, "getStarTransactions", params, &result)
-	return &result, err
+	result := &models.StarTransactions{}
+	err := b.rawRequest(ctx, "getStarTransactions", params, result)
+	return result, err
 }
 
 // RefundStarPayment https://core.telegram.org/bots/api#refundstarpayment
@@ -889,10 +903,12 @@ func (b *Bot) SendGame(ctx context.Context, params *SendGameParams) (*models.Mes
 }
 
 // SetGameScore https://core.telegram.org/bots/api#setgamescore
+//
+// The message is nil for inline messages
 func (b *Bot) SetGameScore(ctx context.Context, params *SetGameScoreParams) (*models.Message, error) {
-	result := &models.Message{}
+	result := &messageOrTrue{}
 	err := b.rawRequest(ctx, "setGameScore", params, result)
-	return result, err
+	return result.message, err
 }
 
 // GetGameHighScores https://core.telegram.org/bots/api#getgamehighscores
@@ -20,46 +20,60 @@ type DeleteWebhookParams struct {
 
 // SendMessageParams https://core.telegram.org/bots/api#sendmessage
 type SendMessageParams struct {
-	BusinessConnectionID string                     `json:"business_connection_id,omitempty"`
-	ChatID               any                        `json:"chat_id"`
-	MessageThreadID      int                        `json:"message_thread_id,omitempty"`
-	Text                 string                     `json:"text"`
-	ParseMode            models.ParseMode           `json:"parse_mode,omitempty"`
-	Entities             []models.MessageEntity     `json:"entities,omitempty"`
-	LinkPreviewOptions   *models.LinkPreviewOptions `json:"link_preview_options,omitempty"`
-	DisableNotification  bool                       `json:"disable_notification,omitempty"`
-	ProtectContent       bool                       `json:"protect_content,omitempty"`
-	AllowPaidBroadcast   bool                       `json:"allow_paid_broadcast,omitempty"`
-	MessageEffectID      string                     `json:"message_effect_id,omitempty"`
-	ReplyParameters      *models.ReplyParameters    `json:"reply_parameters,omitempty"`
-	ReplyMarkup          models.ReplyMarkup         `json:"reply_markup,omitempty"`
+	BusinessConnectionID string `json:"business_connection_id,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID              any                        `json:"chat_id"`
+	Chat                models.ChatID              `json:"-"`
+	MessageThreadID     int                        `json:"message_thread_id,omitempty"`
+	Text                string                     `json:"text"`
+	ParseMode           models.ParseMode           `json:"parse_mode,omitempty"`
+	Entities            []models.MessageEntity     `json:"entities,omitempty"`
+	LinkPreviewOptions  *models.LinkPreviewOptions `json:"link_preview_options,omitempty"`
+	DisableNotification bool                       `json:"disable_notification,omitempty"`
+	ProtectContent      bool                       `json:"protect_content,omitempty"`
+	AllowPaidBroadcast  bool                       `json:"allow_paid_broadcast,omitempty"`
+	MessageEffectID     string                     `json:"message_effect_id,omitempty"`
+	ReplyParameters     *models.ReplyParameters    `json:"reply_parameters,omitempty"`
+	ReplyMarkup         models.ReplyMarkup         `json:"reply_markup,omitempty"`
 }
 
 // ForwardMessageParams https://core.telegram.org/bots/api#forwardmessage
 type ForwardMessageParams struct {
-	ChatID              any  `json:"chat_id"`
-	MessageThreadID     int  `json:"message_thread_id,omitempty"`
-	FromChatID          any  `json:"from_chat_id"`
-	DisableNotification bool `json:"disable_notification,omitempty"`
-	ProtectContent      bool `json:"protect_content,omitempty"`
-	MessageID           int  `json:"message_id"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID          any           `json:"chat_id"`
+	Chat            models.ChatID `json:"-"`
+	MessageThreadID int           `json:"message_thread_id,omitempty"`
+	// FromChatID is kept for compatibility, prefer FromChat
+	FromChatID          any           `json:"from_chat_id"`
+	FromChat            models.ChatID `json:"-"`
+	DisableNotification bool          `json:"disable_notification,omitempty"`
+	ProtectContent      bool          `json:"protect_content,omitempty"`
+	MessageID           int           `json:"message_id"`
 }
 
 // ForwardMessagesParams https://core.telegram.org/bots/api#forwardmessages
 type ForwardMessagesParams struct {
-	ChatID              any   `json:"chat_id"`
-	MessageThreadID     int   `json:"message_thread_id,omitempty"`
-	FromChatID          any   `json:"from_chat_id"`
-	MessageIDs          []int `json:"message_ids"`
-	DisableNotification bool  `json:"disable_notification,omitempty"`
-	ProtectContent      bool  `json:"protect_content,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID          any           `json:"chat_id"`
+	Chat            models.ChatID `json:"-"`
+	MessageThreadID int           `json:"message_thread_id,omitempty"`
+	// FromChatID is kept for compatibility, prefer FromChat
+	FromChatID          any           `json:"from_chat_id"`
+	FromChat            models.ChatID `json:"-"`
+	MessageIDs          []int         `json:"message_ids"`
+	DisableNotification bool          `json:"disable_notification,omitempty"`
+	ProtectContent      bool          `json:"protect_content,omitempty"`
 }
 
 // CopyMessageParams https://core.telegram.org/bots/api#copymessage
 type CopyMessageParams struct {
-	ChatID                any                     `json:"chat_id"`
-	MessageThreadID       int                     `json:"message_thread_id,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID          any           `json:"chat_id"`
+	Chat            models.ChatID `json:"-"`
+	MessageThreadID int           `json:"message_thread_id,omitempty"`
+	// FromChatID is kept for compatibility, prefer FromChat
 	FromChatID            any                     `json:"from_chat_id"`
+	FromChat              models.ChatID           `json:"-"`
 	MessageID             int                     `json:"message_id"`
 	Caption               string                  `json:"caption,omitempty"`
 	ParseMode             models.ParseMode        `json:"parse_mode,omitempty"`
@@ -74,19 +88,25 @@ type CopyMessageParams struct {
 
 // CopyMessagesParams https://core.telegram.org/bots/api#copymessages
 type CopyMessagesParams struct {
-	ChatID              any   `json:"chat_id"`
-	MessageThreadID     int   `json:"message_thread_id,omitempty"`
-	FromChatID          any   `json:"from_chat_id"`
-	MessageIDs          []int `json:"message_ids"`
-	DisableNotification bool  `json:"disable_notification,omitempty"`
-	ProtectContent      bool  `json:"protect_content,omitempty"`
-	RemoveCaption       bool  `json:"remove_caption,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID          any           `json:"chat_id"`
+	Chat            models.ChatID `json:"-"`
+	MessageThreadID int           `json:"message_thread_id,omitempty"`
+	// FromChatID is kept for compatibility, prefer FromChat
+	FromChatID          any           `json:"from_chat_id"`
+	FromChat            models.ChatID `json:"-"`
+	MessageIDs          []int         `json:"message_ids"`
+	DisableNotification bool          `json:"disable_notification,omitempty"`
+	ProtectContent      bool          `json:"protect_content,omitempty"`
+	RemoveCaption       bool          `json:"remove_caption,omitempty"`
 }
 
 // SendPhotoParams https://core.telegram.org/bots/api#sendphoto
 type SendPhotoParams struct {
-	BusinessConnectionID  string                  `json:"business_connection_id,omitempty"`
+	BusinessConnectionID string `json:"business_connection_id,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
 	ChatID                any                     `json:"chat_id"`
+	Chat                  models.ChatID           `json:"-"`
 	MessageThreadID       int                     `json:"message_thread_id,omitempty"`
 	Photo                 models.InputFile        `json:"photo"`
 	Caption               string                  `json:"caption,omitempty"`
@@ -104,29 +124,33 @@ type SendPhotoParams struct {
 
 // SendAudioParams https://core.telegram.org/bots/api#sendaudio
 type SendAudioParams struct {
-	BusinessConnectionID string                  `json:"business_connection_id,omitempty"`
-	ChatID               any                     `json:"chat_id"`
-	MessageThreadID      int                     `json:"message_thread_id,omitempty"`
-	Audio                models.InputFile        `json:"audio"`
-	Caption              string                  `json:"caption,omitempty"`
-	ParseMode            models.ParseMode        `json:"parse_mode,omitempty"`
-	CaptionEntities      []models.MessageEntity  `json:"caption_entities,omitempty"`
-	Duration             int                     `json:"duration,omitempty"`
-	Performer            string                  `json:"performer,omitempty"`
-	Title                string                  `json:"title,omitempty"`
-	Thumbnail            models.InputFile        `json:"thumbnail,omitempty"`
-	DisableNotification  bool                    `json:"disable_notification,omitempty"`
-	ProtectContent       bool                    `json:"protect_content,omitempty"`
-	AllowPaidBroadcast   bool                    `json:"allow_paid_broadcast,omitempty"`
-	MessageEffectID      string                  `json:"message_effect_id,omitempty"`
-	ReplyParameters      *models.ReplyParameters `json:"reply_parameters,omitempty"`
-	ReplyMarkup          models.ReplyMarkup      `json:"reply_markup,omitempty"`
+	BusinessConnectionID string `json:"business_connection_id,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID              any                     `json:"chat_id"`
+	Chat                models.ChatID           `json:"-"`
+	MessageThreadID     int                     `json:"message_thread_id,omitempty"`
+	Audio               models.InputFile        `json:"audio"`
+	Caption             string                  `json:"caption,omitempty"`
+	ParseMode           models.ParseMode        `json:"parse_mode,omitempty"`
+	CaptionEntities     []models.MessageEntity  `json:"caption_entities,omitempty"`
+	Duration            int                     `json:"duration,omitempty"`
+	Performer           string                  `json:"performer,omitempty"`
+	Title               string                  `json:"title,omitempty"`
+	Thumbnail           models.InputFile        `json:"thumbnail,omitempty"`
+	DisableNotification bool                    `json:"disable_notification,omitempty"`
+	ProtectContent      bool                    `json:"protect_content,omitempty"`
+	AllowPaidBroadcast  bool                    `json:"allow_paid_broadcast,omitempty"`
+	MessageEffectID     string                  `json:"message_effect_id,omitempty"`
+	ReplyParameters     *models.ReplyParameters `json:"reply_parameters,omitempty"`
+	ReplyMarkup         models.ReplyMarkup      `json:"reply_markup,omitempty"`
 }
 
 // SendDocumentParams https://core.telegram.org/bots/api#senddocument
 type SendDocumentParams struct {
-	BusinessConnectionID        string                  `json:"business_connection_id,omitempty"`
+	BusinessConnectionID string `json:"business_connection_id,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
 	ChatID                      any                     `json:"chat_id"`
+	Chat                        models.ChatID           `json:"-"`
 	MessageThreadID             int                     `json:"message_thread_id,omitempty"`
 	Document                    models.InputFile        `json:"document"`
 	Thumbnail                   models.InputFile        `json:"thumbnail,omitempty"`
@@ -144,8 +168,10 @@ type SendDocumentParams struct {
 
 // SendVideoParams https://core.telegram.org/bots/api#sendvideo
 type SendVideoParams struct {
-	BusinessConnectionID  string                  `json:"business_connection_id,omitempty"`
+	BusinessConnectionID string `json:"business_connection_id,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
 	ChatID                any                     `json:"chat_id"`
+	Chat                  models.ChatID           `json:"-"`
 	MessageThreadID       int                     `json:"message_thread_id,omitempty"`
 	Video                 models.InputFile        `json:"video"`
 	Duration              int                     `json:"duration,omitempty"`
@@ -168,8 +194,10 @@ type SendVideoParams struct {
 
 // SendAnimationParams https://core.telegram.org/bots/api#sendanimation
 type SendAnimationParams struct {
-	BusinessConnectionID  string                  `json:"business_connection_id,omitempty"`
+	BusinessConnectionID string `json:"business_connection_id,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
 	ChatID                any                     `json:"chat_id"`
+	Chat                  models.ChatID           `json:"-"`
 	MessageThreadID       int                     `json:"message_thread_id,omitempty"`
 	Animation             models.InputFile        `json:"animation"`
 	Duration              int                     `json:"duration,omitempty"`
@@ -191,43 +219,49 @@ type SendAnimationParams struct {
 
 // SendVoiceParams https://core.telegram.org/bots/api#sendvoice
 type SendVoiceParams struct {
-	BusinessConnectionID string                  `json:"business_connection_id,omitempty"`
-	ChatID               any                     `json:"chat_id"`
-	MessageThreadID      int                     `json:"message_thread_id,omitempty"`
-	Voice                models.InputFile        `json:"voice"`
-	Caption              string                  `json:"caption,omitempty"`
-	ParseMode            models.ParseMode        `json:"parse_mode,omitempty"`
-	CaptionEntities      []models.MessageEntity  `json:"caption_entities,omitempty"`
-	Duration             int                     `json:"duration,omitempty"`
-	DisableNotification  bool                    `json:"disable_notification,omitempty"`
-	ProtectContent       bool                    `json:"protect_content,omitempty"`
-	AllowPaidBroadcast   bool                    `json:"allow_paid_broadcast,omitempty"`
-	MessageEffectID      string                  `json:"message_effect_id,omitempty"`
-	ReplyParameters      *models.ReplyParameters `json:"reply_parameters,omitempty"`
-	ReplyMarkup          models.ReplyMarkup      `json:"reply_markup,omitempty"`
+	BusinessConnectionID string `json:"business_connection_id,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID              any                     `json:"chat_id"`
+	Chat                models.ChatID           `json:"-"`
+	MessageThreadID     int                     `json:"message_thread_id,omitempty"`
+	Voice               models.InputFile        `json:"voice"`
+	Caption             string                  `json:"caption,omitempty"`
+	ParseMode           models.ParseMode        `json:"parse_mode,omitempty"`
+	CaptionEntities     []models.MessageEntity  `json:"caption_entities,omitempty"`
+	Duration            int                     `json:"duration,omitempty"`
+	DisableNotification bool                    `json:"disable_notification,omitempty"`
+	ProtectContent      bool                    `json:"protect_content,omitempty"`
+	AllowPaidBroadcast  bool                    `json:"allow_paid_broadcast,omitempty"`
+	MessageEffectID     string                  `json:"message_effect_id,omitempty"`
+	ReplyParameters     *models.ReplyParameters `json:"reply_parameters,omitempty"`
+	ReplyMarkup         models.ReplyMarkup      `json:"reply_markup,omitempty"`
 }
 
 // SendVideoNoteParams https://core.telegram.org/bots/api#sendvideonote
 type SendVideoNoteParams struct {
-	BusinessConnectionID string                  `json:"business_connection_id,omitempty"`
-	ChatID               any                     `json:"chat_id"`
-	MessageThreadID      int                     `json:"message_thread_id,omitempty"`
-	VideoNote            models.InputFile        `json:"video_note"`
-	Duration             int                     `json:"duration,omitempty"`
-	Length               int                     `json:"length,omitempty"`
-	Thumbnail            models.InputFile        `json:"thumbnail,omitempty"`
-	DisableNotification  bool                    `json:"disable_notification,omitempty"`
-	ProtectContent       bool                    `json:"protect_content,omitempty"`
-	AllowPaidBroadcast   bool                    `json:"allow_paid_broadcast,omitempty"`
-	MessageEffectID      string                  `json:"message_effect_id,omitempty"`
-	ReplyParameters      *models.ReplyParameters `json:"reply_parameters,omitempty"`
-	ReplyMarkup          models.ReplyMarkup      `json:"reply_markup,omitempty"`
+	BusinessConnectionID string `json:"business_connection_id,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID              any                     `json:"chat_id"`
+	Chat                models.ChatID           `json:"-"`
+	MessageThreadID     int                     `json:"message_thread_id,omitempty"`
+	VideoNote           models.InputFile        `json:"video_note"`
+	Duration            int                     `json:"duration,omitempty"`
+	Length              int                     `json:"length,omitempty"`
+	Thumbnail           models.InputFile        `json:"thumbnail,omitempty"`
+	DisableNotification bool                    `json:"disable_notification,omitempty"`
+	ProtectContent      bool                    `json:"protect_content,omitempty"`
+	AllowPaidBroadcast  bool                    `json:"allow_paid_broadcast,omitempty"`
+	MessageEffectID     string                  `json:"message_effect_id,omitempty"`
+	ReplyParameters     *models.ReplyParameters `json:"reply_parameters,omitempty"`
+	ReplyMarkup         models.ReplyMarkup      `json:"reply_markup,omitempty"`
 }
 
 // SendPaidMediaParams https://core.telegram.org/bots/api#sendpaidmedia
 type SendPaidMediaParams struct {
-	BusinessConnectionID  string                  `json:"business_connection_id,omitempty"`
+	BusinessConnectionID string `json:"business_connection_id,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
 	ChatID                any                     `json:"chat_id"`
+	Chat                  models.ChatID           `json:"-"`
 	StarCount             int                     `json:"star_count"`
 	Media                 []models.InputPaidMedia `json:"media"`
 	Payload               string                  `json:"payload,omitempty"`
@@ -244,21 +278,25 @@ type SendPaidMediaParams struct {
 
 // SendMediaGroupParams https://core.telegram.org/bots/api#sendmediagroup
 type SendMediaGroupParams struct {
-	BusinessConnectionID string                  `json:"business_connection_id,omitempty"`
-	ChatID               any                     `json:"chat_id"`
-	MessageThreadID      int                     `json:"message_thread_id,omitempty"`
-	Media                []models.InputMedia     `json:"media"`
-	DisableNotification  bool                    `json:"disable_notification,omitempty"`
-	ProtectContent       bool                    `json:"protect_content,omitempty"`
-	AllowPaidBroadcast   bool                    `json:"allow_paid_broadcast,omitempty"`
-	MessageEffectID      string                  `json:"message_effect_id,omitempty"`
-	ReplyParameters      *models.ReplyParameters `json:"reply_parameters,omitempty"`
+	BusinessConnectionID string `json:"business_connection_id,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID              any                     `json:"chat_id"`
+	Chat                models.ChatID           `json:"-"`
+	MessageThreadID     int                     `json:"message_thread_id,omitempty"`
+	Media               []models.InputMedia     `json:"media"`
+	DisableNotification bool                    `json:"disable_notification,omitempty"`
+	ProtectContent      bool                    `json:"protect_content,omitempty"`
+	AllowPaidBroadcast  bool                    `json:"allow_paid_broadcast,omitempty"`
+	MessageEffectID     string                  `json:"message_effect_id,omitempty"`
+	ReplyParameters     *models.ReplyParameters `json:"reply_parameters,omitempty"`
 }
 
 // SendLocationParams https://core.telegram.org/bots/api#sendlocation
 type SendLocationParams struct {
-	BusinessConnectionID string                  `json:"business_connection_id,omitempty"`
+	BusinessConnectionID string `json:"business_connection_id,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
 	ChatID               any                     `json:"chat_id"`
+	Chat                 models.ChatID           `json:"-"`
 	MessageThreadID      int                     `json:"message_thread_id,omitempty"`
 	Latitude             float64                 `json:"latitude"`
 	Longitude            float64                 `json:"longitude"`
@@ -275,8 +313,10 @@ type SendLocationParams struct {
 }
 
 type EditMessageLiveLocationParams struct {
-	BusinessConnectionID string             `json:"business_connection_id,omitempty"`
+	BusinessConnectionID string `json:"business_connection_id,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
 	ChatID               any                `json:"chat_id,omitempty"`
+	Chat                 models.ChatID      `json:"-"`
 	MessageID            int                `json:"message_id,omitempty"`
 	InlineMessageID      string             `json:"inline_message_id,omitempty"`
 	Latitude             float64            `json:"latitude"`
@@ -289,55 +329,63 @@ type EditMessageLiveLocationParams struct {
 }
 
 type StopMessageLiveLocationParams struct {
-	BusinessConnectionID string             `json:"business_connection_id,omitempty"`
-	ChatID               any                `json:"chat_id,omitempty"`
-	MessageID            int                `json:"message_id,omitempty"`
-	InlineMessageID      string             `json:"inline_message_id,omitempty"`
-	ReplyMarkup          models.ReplyMarkup `json:"reply_markup,omitempty"`
+	BusinessConnectionID string `json:"business_connection_id,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID          any                `json:"chat_id,omitempty"`
+	Chat            models.ChatID      `json:"-"`
+	MessageID       int                `json:"message_id,omitempty"`
+	InlineMessageID string             `json:"inline_message_id,omitempty"`
+	ReplyMarkup     models.ReplyMarkup `json:"reply_markup,omitempty"`
 }
 
 // SendVenueParams https://core.telegram.org/bots/api#sendvenue
 type SendVenueParams struct {
-	BusinessConnectionID string                  `json:"business_connection_id,omitempty"`
-	ChatID               any                     `json:"chat_id"`
-	MessageThreadID      int                     `json:"message_thread_id,omitempty"`
-	Latitude             float64                 `json:"latitude"`
-	Longitude            float64                 `json:"longitude"`
-	Title                string                  `json:"title"`
-	Address              string                  `json:"address"`
-	FoursquareID         string                  `json:"foursquare_id,omitempty"`
-	FoursquareType       string                  `json:"foursquare_type,omitempty"`
-	GooglePlaceID        string                  `json:"google_place_id,omitempty"`
-	GooglePlaceType      string                  `json:"google_place_type,omitempty"`
-	DisableNotification  bool                    `json:"disable_notification,omitempty"`
-	ProtectContent       bool                    `json:"protect_content,omitempty"`
-	AllowPaidBroadcast   bool                    `json:"allow_paid_broadcast,omitempty"`
-	MessageEffectID      string                  `json:"message_effect_id,omitempty"`
-	ReplyParameters      *models.ReplyParameters `json:"reply_parameters,omitempty"`
-	ReplyMarkup          models.ReplyMarkup      `json:"reply_markup,omitempty"`
+	BusinessConnectionID string `json:"business_connection_id,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID              any                     `json:"chat_id"`
+	Chat                models.ChatID           `json:"-"`
+	MessageThreadID     int                     `json:"message_thread_id,omitempty"`
+	Latitude            float64                 `json:"latitude"`
+	Longitude           float64                 `json:"longitude"`
+	Title               string                  `json:"title"`
+	Address             string                  `json:"address"`
+	FoursquareID        string                  `json:"foursquare_id,omitempty"`
+	FoursquareType      string                  `json:"foursquare_type,omitempty"`
+	GooglePlaceID       string                  `json:"google_place_id,omitempty"`
+	GooglePlaceType     string                  `json:"google_place_type,omitempty"`
+	DisableNotification bool                    `json:"disable_notification,omitempty"`
+	ProtectContent      bool                    `json:"protect_content,omitempty"`
+	AllowPaidBroadcast  bool                    `json:"allow_paid_broadcast,omitempty"`
+	MessageEffectID     string                  `json:"message_effect_id,omitempty"`
+	ReplyParameters     *models.ReplyParameters `json:"reply_parameters,omitempty"`
+	ReplyMarkup         models.ReplyMarkup      `json:"reply_markup,omitempty"`
 }
 
 // SendContactParams https://core.telegram.org/bots/api#sendcontact
 type SendContactParams struct {
-	BusinessConnectionID string                  `json:"business_connection_id,omitempty"`
-	ChatID               any                     `json:"chat_id"`
-	MessageThreadID      int                     `json:"message_thread_id,omitempty"`
-	PhoneNumber          string                  `json:"phone_number"`
-	FirstName            string                  `json:"first_name"`
-	LastName             string                  `json:"last_name,omitempty"`
-	VCard                string                  `json:"vcard,omitempty"`
-	DisableNotification  bool                    `json:"disable_notification,omitempty"`
-	ProtectContent       bool                    `json:"protect_content,omitempty"`
-	AllowPaidBroadcast   bool                    `json:"allow_paid_broadcast,omitempty"`
-	MessageEffectID      string                  `json:"message_effect_id,omitempty"`
-	ReplyParameters      *models.ReplyParameters `json:"reply_parameters,omitempty"`
-	ReplyMarkup          models.ReplyMarkup      `json:"reply_markup,omitempty"`
+	BusinessConnectionID string `json:"business_connection_id,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID              any                     `json:"chat_id"`
+	Chat                models.ChatID           `json:"-"`
+	MessageThreadID     int                     `json:"message_thread_id,omitempty"`
+	PhoneNumber         string                  `json:"phone_number"`
+	FirstName           string                  `json:"first_name"`
+	LastName            string                  `json:"last_name,omitempty"`
+	VCard               string                  `json:"vcard,omitempty"`
+	DisableNotification bool                    `json:"disable_notification,omitempty"`
+	ProtectContent      bool                    `json:"protect_content,omitempty"`
+	AllowPaidBroadcast  bool                    `json:"allow_paid_broadcast,omitempty"`
+	MessageEffectID     string                  `json:"message_effect_id,omitempty"`
+	ReplyParameters     *models.ReplyParameters `json:"reply_parameters,omitempty"`
+	ReplyMarkup         models.ReplyMarkup      `json:"reply_markup,omitempty"`
 }
 
 // SendPollParams https://core.telegram.org/bots/api#sendpoll
 type SendPollParams struct {
-	BusinessConnectionID  string                   `json:"business_connection_id,omitempty"`
+	BusinessConnectionID string `json:"business_connection_id,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
 	ChatID                any                      `json:"chat_id"`
+	Chat                  models.ChatID            `json:"-"`
 	MessageThreadID       int                      `json:"message_thread_id,omitempty"`
 	Question              string                   `json:"question"`
 	QuestionParseMode     models.ParseMode         `json:"question_parse_mode,omitempty"`
@@ -346,7 +394,7 @@ type SendPollParams struct {
 	IsAnonymous           *bool                    `json:"is_anonymous,omitempty"`
 	Type                  string                   `json:"type,omitempty"`
 	AllowsMultipleAnswers bool                     `json:"allows_multiple_answers,omitempty"`
-	CorrectOptionID       int                      `json:"correct_option_id"`
+	CorrectOptionID       *int                     `json:"correct_option_id,omitempty"`
 	Explanation           string                   `json:"explanation,omitempty"`
 	ExplanationParseMode  string                   `json:"explanation_parse_mode,omitempty"`
 	ExplanationEntities   []models.MessageEntity   `json:"explanation_entities,omitempty"`
@@ -363,28 +411,34 @@ type SendPollParams struct {
 
 // SendDiceParams https://core.telegram.org/bots/api#senddice
 type SendDiceParams struct {
-	BusinessConnectionID string                  `json:"business_connection_id,omitempty"`
-	ChatID               any                     `json:"chat_id"`
-	MessageThreadID      int                     `json:"message_thread_id,omitempty"`
-	Emoji                string                  `json:"emoji,omitempty"`
-	DisableNotification  bool                    `json:"disable_notification,omitempty"`
-	ProtectContent       bool                    `json:"protect_content,omitempty"`
-	AllowPaidBroadcast   bool                    `json:"allow_paid_broadcast,omitempty"`
-	MessageEffectID      string                  `json:"message_effect_id,omitempty"`
-	ReplyParameters      *models.ReplyParameters `json:"reply_parameters,omitempty"`
-	ReplyMarkup          models.ReplyMarkup      `json:"reply_markup,omitempty"`
+	BusinessConnectionID string `json:"business_connection_id,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID              any                     `json:"chat_id"`
+	Chat                models.ChatID           `json:"-"`
+	MessageThreadID     int                     `json:"message_thread_id,omitempty"`
+	Emoji               string                  `json:"emoji,omitempty"`
+	DisableNotification bool                    `json:"disable_notification,omitempty"`
+	ProtectContent      bool                    `json:"protect_content,omitempty"`
+	AllowPaidBroadcast  bool                    `json:"allow_paid_broadcast,omitempty"`
+	MessageEffectID     string                  `json:"message_effect_id,omitempty"`
+	ReplyParameters     *models.ReplyParameters `json:"reply_parameters,omitempty"`
+	ReplyMarkup         models.ReplyMarkup      `json:"reply_markup,omitempty"`
 }
 
 type SendChatActionParams struct {
-	BusinessConnectionID string            `json:"business_connection_id,omitempty"`
-	ChatID               any               `json:"chat_id"`
-	MessageThreadID      int               `json:"message_thread_id,omitempty"`
-	Action               models.ChatAction `json:"action"`
+	BusinessConnectionID string `json:"business_connection_id,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID          any               `json:"chat_id"`
+	Chat            models.ChatID     `json:"-"`
+	MessageThreadID int               `json:"message_thread_id,omitempty"`
+	Action          models.ChatAction `json:"action"`
 }
 
 // SetMessageReactionParams https://core.telegram.org/bots/api#setmessagereaction
 type SetMessageReactionParams struct {
+	// ChatID is kept for compatibility, prefer Chat
 	ChatID    any                   `json:"chat_id"`
+	Chat      models.ChatID         `json:"-"`
 	MessageID int                   `json:"message_id"`
 	Reaction  []models.ReactionType `json:"reaction,omitempty"`
 	IsBig     *bool                 `json:"is_big,omitempty"`
@@ -408,239 +462,322 @@ type GetFileParams struct {
 }
 
 type BanChatMemberParams struct {
-	ChatID         any   `json:"chat_id"`
-	UserID         int64 `json:"user_id"`
-	UntilDate      int   `json:"until_date,omitempty"`
-	RevokeMessages bool  `json:"revoke_messages,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID         any           `json:"chat_id"`
+	Chat           models.ChatID `json:"-"`
+	UserID         int64         `json:"user_id"`
+	UntilDate      int           `json:"until_date,omitempty"`
+	RevokeMessages bool          `json:"revoke_messages,omitempty"`
 }
 
 type UnbanChatMemberParams struct {
-	ChatID       any   `json:"chat_id"`
-	UserID       int64 `json:"user_id"`
-	OnlyIfBanned bool  `json:"only_if_banned,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID       any           `json:"chat_id"`
+	Chat         models.ChatID `json:"-"`
+	UserID       int64         `json:"user_id"`
+	OnlyIfBanned bool          `json:"only_if_banned,omitempty"`
 }
 
 type RestrictChatMemberParams struct {
+	// ChatID is kept for compatibility, prefer Chat
 	ChatID                        any                     `json:"chat_id"`
+	Chat                          models.ChatID           `json:"-"`
 	UserID                        int64                   `json:"user_id"`
-	Permissions                   *models.ChatPermissions `json:"permissions,omitempty"`
+	Permissions                   *models.ChatPermissions `json:"permissions"`
 	UseIndependentChatPermissions bool                    `json:"use_independent_chat_permissions,omitempty"`
 	UntilDate                     int                     `json:"until_date,omitempty"`
 }
 
 type PromoteChatMemberParams struct {
-	ChatID              any   `json:"chat_id" rules:"required,chat_id"`
-	UserID              int64 `json:"user_id" rules:"required"`
-	IsAnonymous         bool  `json:"is_anonymous,omitempty"`
-	CanManageChat       bool  `json:"can_manage_chat,omitempty"`
-	CanDeleteMessages   bool  `json:"can_delete_messages,omitempty"`
-	CanManageVideoChats bool  `json:"can_manage_video_chats,omitempty"`
-	CanRestrictMembers  bool  `json:"can_restrict_members,omitempty"`
-	CanPromoteMembers   bool  `json:"can_promote_members,omitempty"`
-	CanChangeInfo       bool  `json:"can_change_info,omitempty"`
-	CanInviteUsers      bool  `json:"can_invite_users,omitempty"`
-	CanPostMessages     bool  `json:"can_post_messages,omitempty"`
-	CanEditMessages     bool  `json:"can_edit_messages,omitempty"`
-	CanPinMessages      bool  `json:"can_pin_messages,omitempty"`
-	CanPostStories      bool  `json:"can_post_stories,omitempty"`
-	CanEditStories      bool  `json:"can_edit_stories,omitempty"`
-	CanDeleteStories    bool  `json:"can_delete_stories,omitempty"`
-	CanManageTopics     bool  `json:"can_manage_topics,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID              any           `json:"chat_id" rules:"required,chat_id"`
+	Chat                models.ChatID `json:"-"`
+	UserID              int64         `json:"user_id" rules:"required"`
+	IsAnonymous         bool          `json:"is_anonymous,omitempty"`
+	CanManageChat       bool          `json:"can_manage_chat,omitempty"`
+	CanDeleteMessages   bool          `json:"can_delete_messages,omitempty"`
+	CanManageVideoChats bool          `json:"can_manage_video_chats,omitempty"`
+	CanRestrictMembers  bool          `json:"can_restrict_members,omitempty"`
+	CanPromoteMembers   bool          `json:"can_promote_members,omitempty"`
+	CanChangeInfo       bool          `json:"can_change_info,omitempty"`
+	CanInviteUsers      bool          `json:"can_invite_users,omitempty"`
+	CanPostMessages     bool          `json:"can_post_messages,omitempty"`
+	CanEditMessages     bool          `json:"can_edit_messages,omitempty"`
+	CanPinMessages      bool          `json:"can_pin_messages,omitempty"`
+	CanPostStories      bool          `json:"can_post_stories,omitempty"`
+	CanEditStories      bool          `json:"can_edit_stories,omitempty"`
+	CanDeleteStories    bool          `json:"can_delete_stories,omitempty"`
+	CanManageTopics     bool          `json:"can_manage_topics,omitempty"`
 }
 
 type SetChatAdministratorCustomTitleParams struct {
-	ChatID      any    `json:"chat_id"`
-	UserID      int64  `json:"user_id"`
-	CustomTitle string `json:"custom_title"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID      any           `json:"chat_id"`
+	Chat        models.ChatID `json:"-"`
+	UserID      int64         `json:"user_id"`
+	CustomTitle string        `json:"custom_title"`
 }
 
 type BanChatSenderChatParams struct {
-	ChatID       any `json:"chat_id"`
-	SenderChatID int `json:"sender_chat_id"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID       any           `json:"chat_id"`
+	Chat         models.ChatID `json:"-"`
+	SenderChatID int           `json:"sender_chat_id"`
 }
 
 type UnbanChatSenderChatParams struct {
-	ChatID       any `json:"chat_id"`
-	SenderChatID int `json:"sender_chat_id"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID       any           `json:"chat_id"`
+	Chat         models.ChatID `json:"-"`
+	SenderChatID int           `json:"sender_chat_id"`
 }
 
 type SetChatPermissionsParams struct {
+	// ChatID is kept for compatibility, prefer Chat
 	ChatID                        any                    `json:"chat_id"`
+	Chat                          models.ChatID          `json:"-"`
 	Permissions                   models.ChatPermissions `json:"permissions"`
 	UseIndependentChatPermissions bool                   `json:"use_independent_chat_permissions,omitempty"`
 }
 
 type ExportChatInviteLinkParams struct {
-	ChatID any `json:"chat_id"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID any           `json:"chat_id"`
+	Chat   models.ChatID `json:"-"`
 }
 
 type CreateChatInviteLinkParams struct {
-	ChatID             any    `json:"chat_id"`
-	Name               string `json:"name,omitempty"`
-	ExpireDate         int    `json:"expire_date,omitempty"`
-	MemberLimit        int    `json:"member_limit,omitempty"`
-	CreatesJoinRequest bool   `json:"creates_join_request,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID             any           `json:"chat_id"`
+	Chat               models.ChatID `json:"-"`
+	Name               string        `json:"name,omitempty"`
+	ExpireDate         int           `json:"expire_date,omitempty"`
+	MemberLimit        int           `json:"member_limit,omitempty"`
+	CreatesJoinRequest bool          `json:"creates_join_request,omitempty"`
 }
 
 type EditChatInviteLinkParams struct {
-	ChatID             any    `json:"chat_id"`
-	InviteLink         string `json:"invite_link"`
-	Name               string `json:"name,omitempty"`
-	ExpireDate         int    `json:"expire_date,omitempty"`
-	MemberLimit        int    `json:"member_limit,omitempty"`
-	CreatesJoinRequest bool   `json:"creates_join_request,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID             any           `json:"chat_id"`
+	Chat               models.ChatID `json:"-"`
+	InviteLink         string        `json:"invite_link"`
+	Name               string        `json:"name,omitempty"`
+	ExpireDate         int           `json:"expire_date,omitempty"`
+	MemberLimit        int           `json:"member_limit,omitempty"`
+	CreatesJoinRequest bool          `json:"creates_join_request,omitempty"`
 }
 
 type CreateChatSubscriptionInviteLinkParams struct {
-	ChatID             any    `json:"chat_id"`
-	Name               string `json:"name,omitempty"`
-	SubscriptionPeriod int    `json:"subscription_period"`
-	SubscriptionPrice  int    `json:"subscription_price"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID             any           `json:"chat_id"`
+	Chat               models.ChatID `json:"-"`
+	Name               string        `json:"name,omitempty"`
+	SubscriptionPeriod int           `json:"subscription_period"`
+	SubscriptionPrice  int           `json:"subscription_price"`
 }
 
 type EditChatSubscriptionInviteLinkParams struct {
-	ChatID     any    `json:"chat_id"`
-	InviteLink string `json:"invite_link"`
-	Name       string `json:"name,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID     any           `json:"chat_id"`
+	Chat       models.ChatID `json:"-"`
+	InviteLink string        `json:"invite_link"`
+	Name       string        `json:"name,omitempty"`
 }
 
 type RevokeChatInviteLinkParams struct {
-	ChatID     any    `json:"chat_id"`
-	InviteLink string `json:"invite_link"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID     any           `json:"chat_id"`
+	Chat       models.ChatID `json:"-"`
+	InviteLink string        `json:"invite_link"`
 }
 
 type ApproveChatJoinRequestParams struct {
-	ChatID any   `json:"chat_id"`
-	UserID int64 `json:"user_id"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID any           `json:"chat_id"`
+	Chat   models.ChatID `json:"-"`
+	UserID int64         `json:"user_id"`
 }
 
 type DeclineChatJoinRequestParams struct {
-	ChatID any   `json:"chat_id"`
-	UserID int64 `json:"user_id"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID any           `json:"chat_id"`
+	Chat   models.ChatID `json:"-"`
+	UserID int64         `json:"user_id"`
 }
 
 type SetChatPhotoParams struct {
+	// ChatID is kept for compatibility, prefer Chat
 	ChatID any              `json:"chat_id"`
+	Chat   models.ChatID    `json:"-"`
 	Photo  models.InputFile `json:"photo"`
 }
 
 type DeleteChatPhotoParams struct {
-	ChatID any `json:"chat_id"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID any           `json:"chat_id"`
+	Chat   models.ChatID `json:"-"`
 }
 
 type SetChatTitleParams struct {
-	ChatID any    `json:"chat_id"`
-	Title  string `json:"title"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID any           `json:"chat_id"`
+	Chat   models.ChatID `json:"-"`
+	Title  string        `json:"title"`
 }
 
 type SetChatDescriptionParams struct {
-	ChatID      any    `json:"chat_id"`
-	Description string `json:"description"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID      any           `json:"chat_id"`
+	Chat        models.ChatID `json:"-"`
+	Description string        `json:"description,omitempty"`
 }
 
 type PinChatMessageParams struct {
-	ChatID              any  `json:"chat_id"`
-	MessageID           int  `json:"message_id"`
-	DisableNotification bool `json:"disable_notification,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID              any           `json:"chat_id"`
+	Chat                models.ChatID `json:"-"`
+	MessageID           int           `json:"message_id"`
+	DisableNotification bool          `json:"disable_notification,omitempty"`
 }
 
 type UnpinChatMessageParams struct {
-	ChatID    any `json:"chat_id"`
-	MessageID int `json:"message_id,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID    any           `json:"chat_id"`
+	Chat      models.ChatID `json:"-"`
+	MessageID int           `json:"message_id,omitempty"`
 }
 
 type UnpinAllChatMessagesParams struct {
-	ChatID any `json:"chat_id"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID any           `json:"chat_id"`
+	Chat   models.ChatID `json:"-"`
 }
 
 type LeaveChatParams struct {
-	ChatID any `json:"chat_id"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID any           `json:"chat_id"`
+	Chat   models.ChatID `json:"-"`
 }
 
 type GetChatParams struct {
-	ChatID any `json:"chat_id"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID any           `json:"chat_id"`
+	Chat   models.ChatID `json:"-"`
 }
 
 type GetChatAdministratorsParams struct {
-	ChatID any `json:"chat_id"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID any           `json:"chat_id"`
+	Chat   models.ChatID `json:"-"`
 }
 
 type GetChatMemberCountParams struct {
-	ChatID any `json:"chat_id"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID any           `json:"chat_id"`
+	Chat   models.ChatID `json:"-"`
 }
 
 type GetChatMemberParams struct {
-	ChatID any   `json:"chat_id"`
-	UserID int64 `json:"user_id"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID any           `json:"chat_id"`
+	Chat   models.ChatID `json:"-"`
+	UserID int64         `json:"user_id"`
 }
 
 type SetChatStickerSetParams struct {
-	ChatID         any    `json:"chat_id"`
-	StickerSetName string `json:"sticker_set_name"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID         any           `json:"chat_id"`
+	Chat           models.ChatID `json:"-"`
+	StickerSetName string        `json:"sticker_set_name"`
 }
 
 type CreateForumTopicParams struct {
-	ChatID            any    `json:"chat_id"`
-	Name              string `json:"name"`
-	IconColor         int    `json:"icon_color,omitempty"`
-	IconCustomEmojiID string `json:"icon_custom_emoji_id,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID            any           `json:"chat_id"`
+	Chat              models.ChatID `json:"-"`
+	Name              string        `json:"name"`
+	IconColor         int           `json:"icon_color,omitempty"`
+	IconCustomEmojiID string        `json:"icon_custom_emoji_id,omitempty"`
 }
 
 type EditForumTopicParams struct {
-	ChatID            any    `json:"chat_id"`
-	MessageThreadID   int    `json:"message_thread_id"`
-	Name              string `json:"name,omitempty"`
-	IconCustomEmojiID string `json:"icon_custom_emoji_id,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID            any           `json:"chat_id"`
+	Chat              models.ChatID `json:"-"`
+	MessageThreadID   int           `json:"message_thread_id"`
+	Name              string        `json:"name,omitempty"`
+	IconCustomEmojiID string        `json:"icon_custom_emoji_id,omitempty"`
 }
 
 type CloseForumTopicParams struct {
-	ChatID          any `json:"chat_id"`
-	MessageThreadID int `json:"message_thread_id"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID          any           `json:"chat_id"`
+	Chat            models.ChatID `json:"-"`
+	MessageThreadID int           `json:"message_thread_id"`
 }
 
 type ReopenForumTopicParams struct {
-	ChatID          any `json:"chat_id"`
-	MessageThreadID int `json:"message_thread_id"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID          any           `json:"chat_id"`
+	Chat            models.ChatID `json:"-"`
+	MessageThreadID int           `json:"message_thread_id"`
 }
 
 type DeleteForumTopicParams struct {
-	ChatID          any `json:"chat_id"`
-	MessageThreadID int `json:"message_thread_id"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID          any           `json:"chat_id"`
+	Chat            models.ChatID `json:"-"`
+	MessageThreadID int           `json:"message_thread_id"`
 }
 
 type UnpinAllForumTopicMessagesParams struct {
-	ChatID          any `json:"chat_id"`
-	MessageThreadID int `json:"message_thread_id"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID          any           `json:"chat_id"`
+	Chat            models.ChatID `json:"-"`
+	MessageThreadID int           `json:"message_thread_id"`
 }
 
 type EditGeneralForumTopicParams struct {
-	ChatID any    `json:"chat_id"`
-	Name   string `json:"name"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID any           `json:"chat_id"`
+	Chat   models.ChatID `json:"-"`
+	Name   string        `json:"name"`
 }
 
 type CloseGeneralForumTopicParams struct {
-	ChatID any `json:"chat_id"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID any           `json:"chat_id"`
+	Chat   models.ChatID `json:"-"`
 }
 
 type ReopenGeneralForumTopicParams struct {
-	ChatID any `json:"chat_id"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID any           `json:"chat_id"`
+	Chat   models.ChatID `json:"-"`
 }
 
 type HideGeneralForumTopicParams struct {
-	ChatID any `json:"chat_id"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID any           `json:"chat_id"`
+	Chat   models.ChatID `json:"-"`
 }
 
 type UnhideGeneralForumTopicParams struct {
-	ChatID any `json:"chat_id"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID any           `json:"chat_id"`
+	Chat   models.ChatID `json:"-"`
 }
 
 type UnpinAllGeneralForumTopicMessagesParams struct {
-	ChatID any `json:"chat_id"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID any           `json:"chat_id"`
+	Chat   models.ChatID `json:"-"`
 }
 
 type DeleteChatStickerSetParams struct {
-	ChatID         any    `json:"chat_id"`
-	StickerSetName string `json:"sticker_set_name"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID any           `json:"chat_id"`
+	Chat   models.ChatID `json:"-"`
 }
 
 type AnswerCallbackQueryParams struct {
@@ -653,8 +790,10 @@ type AnswerCallbackQueryParams struct {
 
 // GetUserChatBoostsParams https://core.telegram.org/bots/api#getuserchatboosts
 type GetUserChatBoostsParams struct {
-	ChatID any `json:"chat_id"`
-	UserID int `json:"user_id"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID any           `json:"chat_id"`
+	Chat   models.ChatID `json:"-"`
+	UserID int           `json:"user_id"`
 }
 
 // GetBusinessConnectionParams https://core.telegram.org/bots/api#getbusinessconnection
@@ -706,12 +845,16 @@ type GetMyShortDescriptionParams struct {
 }
 
 type SetChatMenuButtonParams struct {
+	// ChatID is kept for compatibility, prefer Chat
 	ChatID     any                    `json:"chat_id,omitempty"`
-	MenuButton models.InputMenuButton `json:"menu_button"`
+	Chat       models.ChatID          `json:"-"`
+	MenuButton models.InputMenuButton `json:"menu_button,omitempty"`
 }
 
 type GetChatMenuButtonParams struct {
-	ChatID any `json:"chat_id"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID any           `json:"chat_id,omitempty"`
+	Chat   models.ChatID `json:"-"`
 }
 
 type SetMyDefaultAdministratorRightsParams struct {
@@ -725,79 +868,94 @@ type GetMyDefaultAdministratorRightsParams struct {
 
 // EditMessageTextParams https://core.telegram.org/bots/api#editmessagetext
 type EditMessageTextParams struct {
-	BusinessConnectionID string                     `json:"business_connection_id,omitempty"`
-	ChatID               any                        `json:"chat_id,omitempty"`
-	MessageID            int                        `json:"message_id,omitempty"`
-	InlineMessageID      string                     `json:"inline_message_id,omitempty"`
-	Text                 string                     `json:"text"`
-	ParseMode            models.ParseMode           `json:"parse_mode,omitempty"`
-	Entities             []models.MessageEntity     `json:"entities,omitempty"`
-	LinkPreviewOptions   *models.LinkPreviewOptions `json:"link_preview_options,omitempty"`
-	ReplyMarkup          models.ReplyMarkup         `json:"reply_markup,omitempty"`
+	BusinessConnectionID string `json:"business_connection_id,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID             any                        `json:"chat_id,omitempty"`
+	Chat               models.ChatID              `json:"-"`
+	MessageID          int                        `json:"message_id,omitempty"`
+	InlineMessageID    string                     `json:"inline_message_id,omitempty"`
+	Text               string                     `json:"text"`
+	ParseMode          models.ParseMode           `json:"parse_mode,omitempty"`
+	Entities           []models.MessageEntity     `json:"entities,omitempty"`
+	LinkPreviewOptions *models.LinkPreviewOptions `json:"link_preview_options,omitempty"`
+	ReplyMarkup        models.ReplyMarkup         `json:"reply_markup,omitempty"`
 }
 
 type EditMessageCaptionParams struct {
-	BusinessConnectionID  string                 `json:"business_connection_id,omitempty"`
+	BusinessConnectionID string `json:"business_connection_id,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
 	ChatID                any                    `json:"chat_id,omitempty"`
+	Chat                  models.ChatID          `json:"-"`
 	MessageID             int                    `json:"message_id,omitempty"`
 	InlineMessageID       string                 `json:"inline_message_id,omitempty"`
 	Caption               string                 `json:"caption,omitempty"`
 	ParseMode             models.ParseMode       `json:"parse_mode,omitempty"`
 	CaptionEntities       []models.MessageEntity `json:"caption_entities,omitempty"`
-	ShowCaptionAboveMedia bool                   `json:"k,omitempty"`
-	DisableWebPagePreview bool                   `json:"disable_web_page_preview,omitempty"`
+	ShowCaptionAboveMedia bool                   `json:"show_caption_above_media,omitempty"`
 	ReplyMarkup           models.ReplyMarkup     `json:"reply_markup,omitempty"`
 }
 
 type EditMessageMediaParams struct {
-	BusinessConnectionID string             `json:"business_connection_id,omitempty"`
-	ChatID               any                `json:"chat_id,omitempty"`
-	MessageID            int                `json:"message_id,omitempty"`
-	InlineMessageID      string             `json:"inline_message_id,omitempty"`
-	Media                models.InputMedia  `json:"media"`
-	ReplyMarkup          models.ReplyMarkup `json:"reply_markup,omitempty"`
+	BusinessConnectionID string `json:"business_connection_id,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID          any                `json:"chat_id,omitempty"`
+	Chat            models.ChatID      `json:"-"`
+	MessageID       int                `json:"message_id,omitempty"`
+	InlineMessageID string             `json:"inline_message_id,omitempty"`
+	Media           models.InputMedia  `json:"media"`
+	ReplyMarkup     models.ReplyMarkup `json:"reply_markup,omitempty"`
 }
 
 type EditMessageReplyMarkupParams struct {
-	BusinessConnectionID string             `json:"business_connection_id,omitempty"`
-	ChatID               any                `json:"chat_id,omitempty"`
-	MessageID            int                `json:"message_id,omitempty"`
-	InlineMessageID      string             `json:"inline_message_id,omitempty"`
-	ReplyMarkup          models.ReplyMarkup `json:"reply_markup,omitempty"`
+	BusinessConnectionID string `json:"business_connection_id,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID          any                `json:"chat_id,omitempty"`
+	Chat            models.ChatID      `json:"-"`
+	MessageID       int                `json:"message_id,omitempty"`
+	InlineMessageID string             `json:"inline_message_id,omitempty"`
+	ReplyMarkup     models.ReplyMarkup `json:"reply_markup,omitempty"`
 }
 
 type StopPollParams struct {
-	BusinessConnectionID string             `json:"business_connection_id,omitempty"`
-	ChatID               any                `json:"chat_id"`
-	MessageID            int                `json:"message_id"`
-	ReplyMarkup          models.ReplyMarkup `json:"reply_markup,omitempty"`
+	BusinessConnectionID string `json:"business_connection_id,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID      any                `json:"chat_id"`
+	Chat        models.ChatID      `json:"-"`
+	MessageID   int                `json:"message_id"`
+	ReplyMarkup models.ReplyMarkup `json:"reply_markup,omitempty"`
 }
 
 // DeleteMessageParams https://core.telegram.org/bots/api#deletemessage
 type DeleteMessageParams struct {
-	ChatID    any `json:"chat_id"`
-	MessageID int `json:"message_id"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID    any           `json:"chat_id"`
+	Chat      models.ChatID `json:"-"`
+	MessageID int           `json:"message_id"`
 }
 
 // DeleteMessagesParams https://core.telegram.org/bots/api#deletemessages
 type DeleteMessagesParams struct {
-	ChatID     any   `json:"chat_id"`
-	MessageIDs []int `json:"message_ids"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID     any           `json:"chat_id"`
+	Chat       models.ChatID `json:"-"`
+	MessageIDs []int         `json:"message_ids"`
 }
 
 // SendStickerParams https://core.telegram.org/bots/api#sendsticker
 type SendStickerParams struct {
-	BusinessConnectionID string                  `json:"business_connection_id,omitempty"`
-	ChatID               any                     `json:"chat_id"`
-	MessageThreadID      int                     `json:"message_thread_id,omitempty"`
-	Sticker              models.InputFile        `json:"sticker"`
-	Emoji                string                  `json:"emoji,omitempty"`
-	DisableNotification  bool                    `json:"disable_notification,omitempty"`
-	ProtectContent       bool                    `json:"protect_content,omitempty"`
-	AllowPaidBroadcast   bool                    `json:"allow_paid_broadcast,omitempty"`
-	MessageEffectID      string                  `json:"message_effect_id,omitempty"`
-	ReplyParameters      *models.ReplyParameters `json:"reply_parameters,omitempty"`
-	ReplyMarkup          models.ReplyMarkup      `json:"reply_markup,omitempty"`
+	BusinessConnectionID string `json:"business_connection_id,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID              any                     `json:"chat_id"`
+	Chat                models.ChatID           `json:"-"`
+	MessageThreadID     int                     `json:"message_thread_id,omitempty"`
+	Sticker             models.InputFile        `json:"sticker"`
+	Emoji               string                  `json:"emoji,omitempty"`
+	DisableNotification bool                    `json:"disable_notification,omitempty"`
+	ProtectContent      bool                    `json:"protect_content,omitempty"`
+	AllowPaidBroadcast  bool                    `json:"allow_paid_broadcast,omitempty"`
+	MessageEffectID     string                  `json:"message_effect_id,omitempty"`
+	ReplyParameters     *models.ReplyParameters `json:"reply_parameters,omitempty"`
+	ReplyMarkup         models.ReplyMarkup      `json:"reply_markup,omitempty"`
 }
 
 type GetStickerSetParams struct {
@@ -809,8 +967,9 @@ type GetCustomEmojiStickersParams struct {
 }
 
 type UploadStickerFileParams struct {
-	UserID     int64            `json:"user_id"`
-	PngSticker models.InputFile `json:"png_sticker"`
+	UserID        int64            `json:"user_id"`
+	Sticker       models.InputFile `json:"sticker"`
+	StickerFormat string           `json:"sticker_format"`
 }
 
 type CreateNewStickerSetParams struct {
@@ -906,7 +1065,9 @@ type SavePreparedInlineMessageParams struct {
 
 // SendInvoiceParams https://core.telegram.org/bots/api#sendinvoice
 type SendInvoiceParams struct {
+	// ChatID is kept for compatibility, prefer Chat
 	ChatID                    any                     `json:"chat_id"`
+	Chat                      models.ChatID           `json:"-"`
 	MessageThreadID           int                     `json:"message_thread_id,omitempty"`
 	Title                     string                  `json:"title"`
 	Description               string                  `json:"description"`
@@ -999,16 +1160,18 @@ type SetPassportDataErrorsParams struct {
 
 // SendGameParams https://core.telegram.org/bots/api#sendgame
 type SendGameParams struct {
-	BusinessConnectionID string                  `json:"business_connection_id,omitempty"`
-	ChatID               any                     `json:"chat_id"`
-	MessageThreadID      int                     `json:"message_thread_id,omitempty"`
-	GameShorName         string                  `json:"game_short_name"`
-	DisableNotification  bool                    `json:"disable_notification,omitempty"`
-	ProtectContent       bool                    `json:"protect_content,omitempty"`
-	AllowPaidBroadcast   bool                    `json:"allow_paid_broadcast,omitempty"`
-	MessageEffectID      string                  `json:"message_effect_id,omitempty"`
-	ReplyParameters      *models.ReplyParameters `json:"reply_parameters,omitempty"`
-	ReplyMarkup          models.ReplyMarkup      `json:"reply_markup,omitempty"`
+	BusinessConnectionID string `json:"business_connection_id,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID              any                     `json:"chat_id"`
+	Chat                models.ChatID           `json:"-"`
+	MessageThreadID     int                     `json:"message_thread_id,omitempty"`
+	GameShorName        string                  `json:"game_short_name"`
+	DisableNotification bool                    `json:"disable_notification,omitempty"`
+	ProtectContent      bool                    `json:"protect_content,omitempty"`
+	AllowPaidBroadcast  bool                    `json:"allow_paid_broadcast,omitempty"`
+	MessageEffectID     string                  `json:"message_effect_id,omitempty"`
+	ReplyParameters     *models.ReplyParameters `json:"reply_parameters,omitempty"`
+	ReplyMarkup         models.ReplyMarkup      `json:"reply_markup,omitempty"`
 }
 
 type SetGameScoreParams struct {
@@ -1016,16 +1179,20 @@ type SetGameScoreParams struct {
 	Score              int   `json:"score"`
 	Force              bool  `json:"force,omitempty"`
 	DisableEditMessage bool  `json:"disable_edit_message,omitempty"`
-	ChatID             any   `json:"chat_id,omitempty"`
-	MessageID          int   `json:"message_id,omitempty"`
-	InlineMessageID    int   `json:"inline_message_id,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID          any           `json:"chat_id,omitempty"`
+	Chat            models.ChatID `json:"-"`
+	MessageID       int           `json:"message_id,omitempty"`
+	InlineMessageID string        `json:"inline_message_id,omitempty"`
 }
 
 type GetGameHighScoresParams struct {
-	UserID          int64 `json:"user_id"`
-	ChatID          any   `json:"chat_id,omitempty"`
-	MessageID       int   `json:"message_id,omitempty"`
-	InlineMessageID int   `json:"inline_message_id,omitempty"`
+	UserID int64 `json:"user_id"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID          any           `json:"chat_id,omitempty"`
+	Chat            models.ChatID `json:"-"`
+	MessageID       int           `json:"message_id,omitempty"`
+	InlineMessageID string        `json:"inline_message_id,omitempty"`
 }
 
 // SendGiftParams https://core.telegram.org/bots/api#sendgift
@@ -1046,8 +1213,10 @@ type VerifyUserParams struct {
 
 // VerifyChatParams https://core.telegram.org/bots/api#verifychat
 type VerifyChatParams struct {
-	ChatID            any    `json:"chat_id"`
-	CustomDescription string `json:"custom_description,omitempty"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID            any           `json:"chat_id"`
+	Chat              models.ChatID `json:"-"`
+	CustomDescription string        `json:"custom_description,omitempty"`
 }
 
 // RemoveUserVerificationParams https://core.telegram.org/bots/api#removeuserverification
@@ -1057,5 +1226,7 @@ type RemoveUserVerificationParams struct {
 
 // RemoveChatVerificationParams https://core.telegram.org/bots/api#removechatverification
 type RemoveChatVerificationParams struct {
-	ChatID any `json:"chat_id"`
+	// ChatID is kept for compatibility, prefer Chat
+	ChatID any           `json:"chat_id"`
+	Chat   models.ChatID `json:"-"`
 }
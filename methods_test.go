@@ -3,6 +3,8 @@ package bot
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -19,10 +21,15 @@ type httpClient struct {
 
 func (c *httpClient) Do(req *http.Request) (*http.Response, error) {
 	if c.reqFields != nil {
+		fields, err := requestFields(req)
+		if err != nil {
+			c.t.Errorf("error read request fields, %v", err)
+			return nil, err
+		}
 		for k, v := range c.reqFields {
-			if v != req.FormValue(k) {
-				c.t.Errorf("invalid field %q value %q, expect %q", k, req.FormValue(k), v)
-				return nil, fmt.Errorf("invalid field %q value %q, expect %q", k, req.FormValue(k), v)
+			if v != fields[k] {
+				c.t.Errorf("invalid field %q value %q, expect %q", k, fields[k], v)
+				return nil, fmt.Errorf("invalid field %q value %q, expect %q", k, fields[k], v)
 			}
 		}
 	}
@@ -33,6 +40,36 @@ func (c *httpClient) Do(req *http.Request) (*http.Response, error) {
 	return resp, nil
 }
 
+// requestFields returns request fields for both multipart/form-data and application/json requests
+// json values are returned as in form-data: strings without quotes, other values as raw json
+func requestFields(req *http.Request) (map[string]string, error) {
+	if req.Header.Get("Content-Type") != "application/json" {
+		if err := req.ParseMultipartForm(32 << 20); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+			return nil, err
+		}
+		fields := map[string]string{}
+		for k := range req.Form {
+			fields[k] = req.FormValue(k)
+		}
+		return fields, nil
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.NewDecoder(req.Body).Decode(&raw); err != nil {
+		return nil, err
+	}
+	fields := map[string]string{}
+	for k, v := range raw {
+		var str string
+		if err := json.Unmarshal(v, &str); err == nil {
+			fields[k] = str
+			continue
+		}
+		fields[k] = string(v)
+	}
+	return fields, nil
+}
+
 func assertNoErr(t *testing.T, err error) {
 	if err != nil {
 		t.Errorf("unexpected error %v", err)
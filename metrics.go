@@ -0,0 +1,302 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-telegram/bot/models"
+)
+
+// defaultMetricsBuckets are histogram buckets in seconds
+var defaultMetricsBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+const (
+	metricTypeCounter   = "counter"
+	metricTypeHistogram = "histogram"
+)
+
+// Metrics collects the bot metrics and serves them in Prometheus text format, see WithMetrics.
+// One Metrics can be shared by many bots
+type Metrics struct {
+	updatesReceived    *metricFamily
+	handlerDuration    *metricFamily
+	handlerRetries     *metricFamily
+	apiRequests        *metricFamily
+	apiRequestDuration *metricFamily
+	tooManyRequests    *metricFamily
+	pollingErrors      *metricFamily
+	webhookRejected    *metricFamily
+
+	families []*metricFamily
+}
+
+// NewMetrics returns new Metrics
+func NewMetrics() *Metrics {
+	m := &Metrics{
+		updatesReceived:    newMetricFamily("telegram_bot_updates_received_total", "Updates received by type.", metricTypeCounter, nil, "type"),
+		handlerDuration:    newMetricFamily("telegram_bot_handler_duration_seconds", "Handler duration by outcome.", metricTypeHistogram, defaultMetricsBuckets, "outcome"),
+		handlerRetries:     newMetricFamily("telegram_bot_handler_retries_total", "Handler retries of failed updates.", metricTypeCounter, nil),
+		apiRequests:        newMetricFamily("telegram_bot_api_requests_total", "Bot API requests by method and error code.", metricTypeCounter, nil, "method", "code"),
+		apiRequestDuration: newMetricFamily("telegram_bot_api_request_duration_seconds", "Bot API request duration by method.", metricTypeHistogram, defaultMetricsBuckets, "method"),
+		tooManyRequests:    newMetricFamily("telegram_bot_api_too_many_requests_total", "Bot API requests failed with 429 Too Many Requests by method.", metricTypeCounter, nil, "method"),
+		pollingErrors:      newMetricFamily("telegram_bot_polling_errors_total", "Failed getUpdates requests.", metricTypeCounter, nil),
+		webhookRejected:    newMetricFamily("telegram_bot_webhook_rejected_total", "Rejected webhook requests by reason.", metricTypeCounter, nil, "reason"),
+	}
+
+	m.families = []*metricFamily{
+		m.updatesReceived, m.handlerDuration, m.handlerRetries, m.apiRequests,
+		m.apiRequestDuration, m.tooManyRequests, m.pollingErrors, m.webhookRejected,
+	}
+
+	return m
+}
+
+// Handler returns http handler, which serves metrics in Prometheus text exposition format
+func (m *Metrics) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		buf := &bytes.Buffer{}
+		for _, f := range m.families {
+			f.write(buf)
+		}
+
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_, _ = w.Write(buf.Bytes())
+	})
+}
+
+func (m *Metrics) updateReceived(upd *models.Update) {
+	if m == nil {
+		return
+	}
+	m.updatesReceived.add(1, updateType(upd))
+}
+
+func (m *Metrics) observeHandler(d time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	outcome := "ok"
+	if err != nil {
+		outcome = "failed"
+	}
+	m.handlerDuration.observe(d.Seconds(), outcome)
+}
+
+func (m *Metrics) handlerRetry() {
+	if m == nil {
+		return
+	}
+	m.handlerRetries.add(1)
+}
+
+func (m *Metrics) observeAPIRequest(method string, d time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	code := apiErrorCode(err)
+	m.apiRequests.add(1, method, code)
+	m.apiRequestDuration.observe(d.Seconds(), method)
+	if code == "429" {
+		m.tooManyRequests.add(1, method)
+	}
+}
+
+func (m *Metrics) pollingError() {
+	if m == nil {
+		return
+	}
+	m.pollingErrors.add(1)
+}
+
+func (m *Metrics) webhookRejection(reason string) {
+	if m == nil {
+		return
+	}
+	m.webhookRejected.add(1, reason)
+}
+
+// apiErrorCode returns the error code label of the Bot API request result
+func apiErrorCode(err error) string {
+	if err == nil {
+		return "ok"
+	}
+
+	var tooManyRequests *TooManyRequestsError
+	var migrate *MigrateError
+
+	switch {
+	case errors.As(err, &tooManyRequests):
+		return "429"
+	case errors.As(err, &migrate), errors.Is(err, ErrorBadRequest):
+		return "400"
+	case errors.Is(err, ErrorUnauthorized):
+		return "401"
+	case errors.Is(err, ErrorForbidden):
+		return "403"
+	case errors.Is(err, ErrorNotFound):
+		return "404"
+	case errors.Is(err, ErrorConflict):
+		return "409"
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return "canceled"
+	}
+
+	return "error"
+}
+
+// updateType returns the json name of the first not empty field of the update
+func updateType(upd *models.Update) string {
+	v := reflect.ValueOf(upd).Elem()
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() != reflect.Ptr || field.IsNil() {
+			continue
+		}
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" {
+			return name
+		}
+	}
+
+	return "unknown"
+}
+
+type metricFamily struct {
+	name       string
+	help       string
+	metricType string
+	labels     []string
+	buckets    []float64
+
+	mx     sync.Mutex
+	series map[string]*metricSeries
+}
+
+type metricSeries struct {
+	labelValues []string
+	value       float64
+	counts      []uint64
+	count       uint64
+}
+
+func newMetricFamily(name, help, metricType string, buckets []float64, labels ...string) *metricFamily {
+	return &metricFamily{
+		name:       name,
+		help:       help,
+		metricType: metricType,
+		labels:     labels,
+		buckets:    buckets,
+		series:     map[string]*metricSeries{},
+	}
+}
+
+func (f *metricFamily) get(labelValues []string) *metricSeries {
+	key := strings.Join(labelValues, "\xff")
+
+	s, ok := f.series[key]
+	if !ok {
+		s = &metricSeries{labelValues: labelValues}
+		if f.metricType == metricTypeHistogram {
+			s.counts = make([]uint64, len(f.buckets))
+		}
+		f.series[key] = s
+	}
+
+	return s
+}
+
+func (f *metricFamily) add(v float64, labelValues ...string) {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	f.get(labelValues).value += v
+}
+
+func (f *metricFamily) observe(v float64, labelValues ...string) {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	s := f.get(labelValues)
+	s.value += v
+	s.count++
+	for i, le := range f.buckets {
+		if v <= le {
+			s.counts[i]++
+		}
+	}
+}
+
+func (f *metricFamily) write(buf *bytes.Buffer) {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	fmt.Fprintf(buf, "# HELP %s %s\n", f.name, f.help)
+	fmt.Fprintf(buf, "# TYPE %s %s\n", f.name, f.metricType)
+
+	keys := make([]string, 0, len(f.series))
+	for key := range f.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		s := f.series[key]
+		labels := formatMetricLabels(f.labels, s.labelValues)
+
+		if f.metricType == metricTypeCounter {
+			fmt.Fprintf(buf, "%s%s %s\n", f.name, wrapMetricLabels(labels), formatMetricValue(s.value))
+			continue
+		}
+
+		for i, le := range f.buckets {
+			bucketLabels := appendMetricLabel(labels, `le="`+formatMetricValue(le)+`"`)
+			fmt.Fprintf(buf, "%s_bucket%s %d\n", f.name, wrapMetricLabels(bucketLabels), s.counts[i])
+		}
+		fmt.Fprintf(buf, "%s_bucket%s %d\n", f.name, wrapMetricLabels(appendMetricLabel(labels, `le="+Inf"`)), s.count)
+		fmt.Fprintf(buf, "%s_sum%s %s\n", f.name, wrapMetricLabels(labels), formatMetricValue(s.value))
+		fmt.Fprintf(buf, "%s_count%s %d\n", f.name, wrapMetricLabels(labels), s.count)
+	}
+}
+
+func formatMetricLabels(names, values []string) string {
+	parts := make([]string, 0, len(names))
+	for i, name := range names {
+		parts = append(parts, name+`="`+escapeMetricLabelValue(values[i])+`"`)
+	}
+	return strings.Join(parts, ",")
+}
+
+func appendMetricLabel(labels, label string) string {
+	if labels == "" {
+		return label
+	}
+	return labels + "," + label
+}
+
+func wrapMetricLabels(labels string) string {
+	if labels == "" {
+		return ""
+	}
+	return "{" + labels + "}"
+}
+
+var metricLabelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeMetricLabelValue(v string) string {
+	return metricLabelValueReplacer.Replace(v)
+}
+
+func formatMetricValue(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
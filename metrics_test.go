@@ -0,0 +1,120 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func scrapeMetrics(t *testing.T, m *Metrics) string {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assertEqualInt(t, rec.Code, http.StatusOK)
+	assertTrue(t, strings.HasPrefix(rec.Header().Get("Content-Type"), "text/plain; version=0.0.4"))
+
+	return rec.Body.String()
+}
+
+func assertContains(t *testing.T, s, substr string) {
+	t.Helper()
+	if !strings.Contains(s, substr) {
+		t.Fatalf("expected %q in\n%s", substr, s)
+	}
+}
+
+func TestMetrics_ProcessUpdate(t *testing.T) {
+	m := NewMetrics()
+
+	b := &Bot{
+		defaultHandlerFunc: func(ctx context.Context, bot *Bot, update *models.Update) {
+			if update.CallbackQuery != nil {
+				FailUpdate(ctx, errors.New("failed"))
+			}
+		},
+		errorsHandler: func(err error) {},
+		metrics:       m,
+	}
+
+	ctx := context.Background()
+	b.processUpdate(ctx, &models.Update{ID: 1, Message: &models.Message{}})
+	b.processUpdate(ctx, &models.Update{ID: 2, Message: &models.Message{}})
+	b.processUpdate(ctx, &models.Update{ID: 3, CallbackQuery: &models.CallbackQuery{}})
+
+	out := scrapeMetrics(t, m)
+
+	assertContains(t, out, "# TYPE telegram_bot_updates_received_total counter\n")
+	assertContains(t, out, `telegram_bot_updates_received_total{type="message"} 2`+"\n")
+	assertContains(t, out, `telegram_bot_updates_received_total{type="callback_query"} 1`+"\n")
+	assertContains(t, out, "# TYPE telegram_bot_handler_duration_seconds histogram\n")
+	assertContains(t, out, `telegram_bot_handler_duration_seconds_bucket{outcome="ok",le="+Inf"} 2`+"\n")
+	assertContains(t, out, `telegram_bot_handler_duration_seconds_count{outcome="failed"} 1`+"\n")
+	assertContains(t, out, `telegram_bot_handler_duration_seconds_bucket{outcome="failed",le="0.005"} 1`+"\n")
+}
+
+func TestMetrics_APIRequests(t *testing.T) {
+	m := NewMetrics()
+
+	s := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/botXXX/sendMessage":
+			_, _ = rw.Write([]byte(`{"ok":false,"error_code":429,"description":"Too Many Requests","parameters":{"retry_after":1}}`))
+		default:
+			_, _ = rw.Write([]byte(`{"ok":true,"result":true}`))
+		}
+	}))
+	defer s.Close()
+
+	b, err := New("XXX", WithServerURL(s.URL), WithSkipGetMe(), WithMetrics(m))
+	assertNoErr(t, err)
+
+	_, err = b.SendMessage(context.Background(), &SendMessageParams{ChatID: 1, Text: "text"})
+	assertTrue(t, err != nil)
+	_, err = b.DeleteMessage(context.Background(), &DeleteMessageParams{ChatID: 1, MessageID: 1})
+	assertNoErr(t, err)
+
+	out := scrapeMetrics(t, m)
+
+	assertContains(t, out, `telegram_bot_api_requests_total{method="sendMessage",code="429"} 1`+"\n")
+	assertContains(t, out, `telegram_bot_api_requests_total{method="deleteMessage",code="ok"} 1`+"\n")
+	assertContains(t, out, `telegram_bot_api_too_many_requests_total{method="sendMessage"} 1`+"\n")
+	assertContains(t, out, `telegram_bot_api_request_duration_seconds_count{method="deleteMessage"} 1`+"\n")
+}
+
+func TestMetrics_WebhookRejected(t *testing.T) {
+	m := NewMetrics()
+
+	b := &Bot{
+		webhookSecretToken: "secret",
+		errorsHandler:      func(err error) {},
+		metrics:            m,
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("{}"))
+	b.WebhookHandler().ServeHTTP(httptest.NewRecorder(), req)
+
+	out := scrapeMetrics(t, m)
+
+	assertContains(t, out, `telegram_bot_webhook_rejected_total{reason="unauthorized"} 1`+"\n")
+	assertContains(t, out, "# TYPE telegram_bot_polling_errors_total counter\n")
+}
+
+func TestAPIErrorCode(t *testing.T) {
+	assertEqualString(t, apiErrorCode(nil), "ok")
+	assertEqualString(t, apiErrorCode(fmt.Errorf("%w, chat not found", ErrorBadRequest)), "400")
+	assertEqualString(t, apiErrorCode(&MigrateError{}), "400")
+	assertEqualString(t, apiErrorCode(&TooManyRequestsError{}), "429")
+	assertEqualString(t, apiErrorCode(fmt.Errorf("error do request, %w", context.Canceled)), "canceled")
+	assertEqualString(t, apiErrorCode(errors.New("network error")), "error")
+}
+
+func TestEscapeMetricLabelValue(t *testing.T) {
+	assertEqualString(t, escapeMetricLabelValue("a\"b\\c\nd"), `a\"b\\c\nd`)
+}
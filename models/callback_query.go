@@ -3,10 +3,10 @@ package models
 // CallbackQuery https://core.telegram.org/bots/api#callbackquery
 type CallbackQuery struct {
 	ID              string                   `json:"id"`
-	From            User                     `json:"from,omitempty"`
+	From            User                     `json:"from"`
 	Message         MaybeInaccessibleMessage `json:"message,omitempty"`
 	InlineMessageID string                   `json:"inline_message_id,omitempty"`
-	ChatInstance    string                   `json:"chat_instance,omitempty"`
+	ChatInstance    string                   `json:"chat_instance"`
 	Data            string                   `json:"data,omitempty"`
 	GameShortName   string                   `json:"game_short_name,omitempty"`
 }
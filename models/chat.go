@@ -29,6 +29,8 @@ type ChatInviteLink struct {
 	ExpireDate              int    `json:"expire_date,omitempty"`
 	MemberLimit             int    `json:"member_limit,omitempty"`
 	PendingJoinRequestCount int    `json:"pending_join_request_count,omitempty"`
+	SubscriptionPeriod      int    `json:"subscription_period,omitempty"`
+	SubscriptionPrice       int    `json:"subscription_price,omitempty"`
 }
 
 // ChatAdministratorRights https://core.telegram.org/bots/api#chatadministratorrights
@@ -44,21 +46,21 @@ type ChatAdministratorRights struct {
 	CanPostMessages     bool `json:"can_post_messages,omitempty"`
 	CanEditMessages     bool `json:"can_edit_messages,omitempty"`
 	CanPinMessages      bool `json:"can_pin_messages,omitempty"`
-	CanPostStories      bool `json:"can_post_stories,omitempty"`
-	CanEditStories      bool `json:"can_edit_stories,omitempty"`
-	CanDeleteStories    bool `json:"can_delete_stories,omitempty"`
+	CanPostStories      bool `json:"can_post_stories"`
+	CanEditStories      bool `json:"can_edit_stories"`
+	CanDeleteStories    bool `json:"can_delete_stories"`
 	CanManageTopics     bool `json:"can_manage_topics,omitempty"`
 }
 
 // ChatPermissions https://core.telegram.org/bots/api#chatpermissions
 type ChatPermissions struct {
 	CanSendMessages       bool `json:"can_send_messages,omitempty"`
-	CanSendAudios         bool `json:"can_send_audios"`
-	CanSendDocuments      bool `json:"can_send_documents"`
-	CanSendPhotos         bool `json:"can_send_photos"`
-	CanSendVideos         bool `json:"can_send_videos"`
-	CanSendVideoNotes     bool `json:"can_send_video_notes"`
-	CanSendVoiceNotes     bool `json:"can_send_voice_notes"`
+	CanSendAudios         bool `json:"can_send_audios,omitempty"`
+	CanSendDocuments      bool `json:"can_send_documents,omitempty"`
+	CanSendPhotos         bool `json:"can_send_photos,omitempty"`
+	CanSendVideos         bool `json:"can_send_videos,omitempty"`
+	CanSendVideoNotes     bool `json:"can_send_video_notes,omitempty"`
+	CanSendVoiceNotes     bool `json:"can_send_voice_notes,omitempty"`
 	CanSendPolls          bool `json:"can_send_polls,omitempty"`
 	CanSendOtherMessages  bool `json:"can_send_other_messages,omitempty"`
 	CanAddWebPagePreviews bool `json:"can_add_web_page_previews,omitempty"`
@@ -117,18 +119,18 @@ type ChatFullInfo struct {
 	BusinessOpeningHours               *BusinessOpeningHours `json:"business_opening_hours,omitempty"`
 	PersonalChat                       *Chat                 `json:"personal_chat,omitempty"`
 	AvailableReactions                 []ReactionType        `json:"available_reactions,omitempty"`
-	AccentColorID                      int                   `json:"accent_color_id,omitempty"`
+	AccentColorID                      int                   `json:"accent_color_id"`
 	MaxReactionCount                   int                   `json:"max_reaction_count"`
 	BackgroundCustomEmojiID            string                `json:"background_custom_emoji_id,omitempty"`
 	ProfileAccentColorID               int                   `json:"profile_accent_color_id,omitempty"`
 	ProfileBackgroundCustomEmojiID     string                `json:"profile_background_custom_emoji_id,omitempty"`
 	EmojiStatusCustomEmojiID           string                `json:"emoji_status_custom_emoji_id,omitempty"`
 	EmojiStatusExpirationDate          int                   `json:"emoji_status_expiration_date,omitempty"`
-	Bio                                string                `json:"bio"`
+	Bio                                string                `json:"bio,omitempty"`
 	HasPrivateForwards                 bool                  `json:"has_private_forwards,omitempty"`
 	HasRestrictedVoiceAndVideoMessages bool                  `json:"has_restricted_voice_and_video_messages,omitempty"`
-	JoinToSendMessages                 bool                  `json:"join_to_send_messages"`
-	JoinByRequest                      bool                  `json:"join_by_request"`
+	JoinToSendMessages                 bool                  `json:"join_to_send_messages,omitempty"`
+	JoinByRequest                      bool                  `json:"join_by_request,omitempty"`
 	Description                        string                `json:"description,omitempty"`
 	InviteLink                         string                `json:"invite_link,omitempty"`
 	PinnedMessage                      *Message              `json:"pinned_message,omitempty"`
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ChatID is the unique identifier of a chat or the username of a channel in the format @channelusername.
+// Use it for Chat and FromChat fields of params
+type ChatID struct {
+	id       int64
+	username string
+}
+
+// NewChatID returns ChatID with the numeric chat identifier
+func NewChatID(id int64) ChatID {
+	return ChatID{id: id}
+}
+
+// NewChatUsername returns ChatID with the channel username, @ is added if it is missing
+func NewChatUsername(username string) ChatID {
+	if !strings.HasPrefix(username, "@") {
+		username = "@" + username
+	}
+	return ChatID{username: username}
+}
+
+// ParseChatID parses the numeric chat identifier or the channel username in the format @channelusername
+func ParseChatID(s string) (ChatID, error) {
+	if strings.HasPrefix(s, "@") {
+		if len(s) == 1 {
+			return ChatID{}, fmt.Errorf("error parse chat id %q, empty username", s)
+		}
+		return ChatID{username: s}, nil
+	}
+
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return ChatID{}, fmt.Errorf("error parse chat id %q, expected integer or @channelusername", s)
+	}
+
+	return ChatID{id: id}, nil
+}
+
+// ID returns the numeric chat identifier, 0 for usernames
+func (c ChatID) ID() int64 {
+	return c.id
+}
+
+// Username returns the channel username with @, empty for numeric identifiers
+func (c ChatID) Username() string {
+	return c.username
+}
+
+// IsZero reports whether the ChatID is not set
+func (c ChatID) IsZero() bool {
+	return c.id == 0 && c.username == ""
+}
+
+func (c ChatID) String() string {
+	if c.username != "" {
+		return c.username
+	}
+	return strconv.FormatInt(c.id, 10)
+}
+
+func (c ChatID) MarshalJSON() ([]byte, error) {
+	if c.username != "" {
+		return json.Marshal(c.username)
+	}
+	return []byte(strconv.FormatInt(c.id, 10)), nil
+}
+
+func (c *ChatID) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(data, []byte(`"`)) {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		id, err := ParseChatID(s)
+		if err != nil {
+			return err
+		}
+		*c = id
+		return nil
+	}
+
+	var id int64
+	if err := json.Unmarshal(data, &id); err != nil {
+		return err
+	}
+	*c = ChatID{id: id}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestChatID_MarshalJSON(t *testing.T) {
+	for _, tt := range []struct {
+		id       ChatID
+		expected string
+	}{
+		{id: NewChatID(-100123), expected: `-100123`},
+		{id: NewChatUsername("channel"), expected: `"@channel"`},
+		{id: NewChatUsername("@channel"), expected: `"@channel"`},
+	} {
+		data, err := json.Marshal(tt.id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != tt.expected {
+			t.Fatalf("unexpected %s, expected %s", data, tt.expected)
+		}
+
+		var id ChatID
+		if err = json.Unmarshal(data, &id); err != nil {
+			t.Fatal(err)
+		}
+		if id != tt.id {
+			t.Fatalf("unexpected %v, expected %v", id, tt.id)
+		}
+	}
+}
+
+func TestParseChatID(t *testing.T) {
+	id, err := ParseChatID("-100123")
+	if err != nil || id.ID() != -100123 || id.Username() != "" {
+		t.Fatalf("unexpected %v, %v", id, err)
+	}
+
+	id, err = ParseChatID("@channel")
+	if err != nil || id.Username() != "@channel" || id.String() != "@channel" {
+		t.Fatalf("unexpected %v, %v", id, err)
+	}
+
+	for _, s := range []string{"channel", "@", ""} {
+		if _, err = ParseChatID(s); err == nil {
+			t.Fatalf("expected error for %q", s)
+		}
+	}
+
+	var c ChatID
+	if err = json.Unmarshal([]byte(`"channel"`), &c); err == nil {
+		t.Fatal("expected error for username without @")
+	}
+}
@@ -127,9 +127,9 @@ type ChatMemberAdministrator struct {
 	CanPostMessages     bool           `json:"can_post_messages,omitempty"`
 	CanEditMessages     bool           `json:"can_edit_messages,omitempty"`
 	CanPinMessages      bool           `json:"can_pin_messages,omitempty"`
-	CanPostStories      bool           `json:"can_post_stories,omitempty"`
-	CanEditStories      bool           `json:"can_edit_stories,omitempty"`
-	CanDeleteStories    bool           `json:"can_delete_stories,omitempty"`
+	CanPostStories      bool           `json:"can_post_stories"`
+	CanEditStories      bool           `json:"can_edit_stories"`
+	CanDeleteStories    bool           `json:"can_delete_stories"`
 	CanManageTopics     bool           `json:"can_manage_topics,omitempty"`
 	CustomTitle         string         `json:"custom_title,omitempty"`
 }
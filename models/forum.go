@@ -4,7 +4,7 @@ package models
 type ForumTopic struct {
 	MessageThreadID   int    `json:"message_thread_id"`
 	Name              string `json:"name"`
-	IconColor         int    `json:"icon_color,omitempty"`
+	IconColor         int    `json:"icon_color"`
 	IconCustomEmojiID string `json:"icon_custom_emoji_id,omitempty"`
 }
 
@@ -28,7 +28,7 @@ type AnswerInlineQuery struct {
 // InlineQueryResultsButton https://core.telegram.org/bots/api#inlinequeryresultsbutton
 type InlineQueryResultsButton struct {
 	Text           string      `json:"text"`
-	WebApp         *WebAppInfo `json:"web_app"`
+	WebApp         *WebAppInfo `json:"web_app,omitempty"`
 	StartParameter string      `json:"start_parameter,omitempty"`
 }
 
@@ -42,8 +42,8 @@ type InlineQueryResult interface {
 // InlineQueryResultArticle https://core.telegram.org/bots/api#inlinequeryresultarticle
 type InlineQueryResultArticle struct {
 	ID                  string              `json:"id"`
-	Title               string              `json:"title,omitempty"`
-	InputMessageContent InputMessageContent `json:"input_message_content,omitempty"`
+	Title               string              `json:"title"`
+	InputMessageContent InputMessageContent `json:"input_message_content"`
 	ReplyMarkup         ReplyMarkup         `json:"reply_markup,omitempty"`
 	URL                 string              `json:"url,omitempty"`
 	Description         string              `json:"description,omitempty"`
@@ -165,7 +165,7 @@ func (m *InlineQueryResultMpeg4Gif) MarshalCustom() ([]byte, error) {
 type InlineQueryResultVideo struct {
 	ID                    string              `json:"id"`
 	VideoURL              string              `json:"video_url"`
-	MimeType              string              `json:"mime_type,omitempty"`
+	MimeType              string              `json:"mime_type"`
 	ThumbnailURL          string              `json:"thumbnail_url"`
 	Title                 string              `json:"title,omitempty"`
 	Caption               string              `json:"caption,omitempty"`
@@ -285,7 +285,7 @@ type InlineQueryResultLocation struct {
 	ID                   string              `json:"id"`
 	Latitude             float64             `json:"latitude"`
 	Longitude            float64             `json:"longitude"`
-	Title                string              `json:"title,omitempty"`
+	Title                string              `json:"title"`
 	HorizontalAccuracy   float64             `json:"horizontal_accuracy,omitempty"`
 	LivePeriod           int                 `json:"live_period,omitempty"`
 	Heading              int                 `json:"heading,omitempty"`
@@ -316,7 +316,7 @@ type InlineQueryResultVenue struct {
 	ID                  string              `json:"id"`
 	Latitude            float64             `json:"latitude"`
 	Longitude           float64             `json:"longitude"`
-	Title               string              `json:"title,omitempty"`
+	Title               string              `json:"title"`
 	Address             string              `json:"address"`
 	FoursquareID        string              `json:"foursquare_id,omitempty"`
 	FoursquareType      string              `json:"foursquare_type,omitempty"`
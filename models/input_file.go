@@ -20,7 +20,7 @@ type InputFileUpload struct {
 func (*InputFileUpload) inputFileTag() {}
 
 func (i *InputFileUpload) MarshalJSON() ([]byte, error) {
-	return []byte(`"@` + i.Filename + `"`), nil
+	return json.Marshal("@" + i.Filename)
 }
 
 type InputFileString struct {
@@ -30,7 +30,7 @@ type InputFileString struct {
 func (*InputFileString) inputFileTag() {}
 
 func (i *InputFileString) MarshalJSON() ([]byte, error) {
-	return []byte(`"` + i.Data + `"`), nil
+	return json.Marshal(i.Data)
 }
 
 func (i *InputFileString) UnmarshalJSON(data []byte) error {
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestInputFileString_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(&InputFileString{Data: `C:\files\"photo".jpg`})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		t.Fatalf("invalid json %s, %v", data, err)
+	}
+	if s != `C:\files\"photo".jpg` {
+		t.Fatalf("unexpected value %q", s)
+	}
+}
+
+func TestInputFileUpload_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(&InputFileUpload{Filename: `a"b.jpg`})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if string(data) != `"@a\"b.jpg"` {
+		t.Fatalf("unexpected json %s", data)
+	}
+}
@@ -29,5 +29,5 @@ type MessageEntity struct {
 	URL           string            `json:"url,omitempty"`
 	User          *User             `json:"user,omitempty"`
 	Language      string            `json:"language,omitempty"`
-	CustomEmojiID string            `json:"custom_emoji_id"`
+	CustomEmojiID string            `json:"custom_emoji_id,omitempty"`
 }
@@ -4,7 +4,7 @@ package models
 type PollAnswer struct {
 	PollID    string `json:"poll_id"`
 	VoterChat *Chat  `json:"voter_chat,omitempty"`
-	User      *User  `json:"user"`
+	User      *User  `json:"user,omitempty"`
 	OptionIDs []int  `json:"option_ids,omitempty"`
 }
 
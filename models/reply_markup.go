@@ -57,7 +57,6 @@ type ReplyKeyboardMarkup struct {
 // KeyboardButton https://core.telegram.org/bots/api#keyboardbutton
 type KeyboardButton struct {
 	Text            string                      `json:"text"`
-	RequestUser     *KeyboardButtonRequestUsers `json:"request_user,omitempty"`
 	RequestUsers    *KeyboardButtonRequestUsers `json:"request_users,omitempty"`
 	RequestChat     *KeyboardButtonRequestChat  `json:"request_chat,omitempty"`
 	RequestContact  bool                        `json:"request_contact,omitempty"`
@@ -104,15 +103,8 @@ type KeyboardButtonPollType struct {
 	Type string `json:"type,omitempty"`
 }
 
-type CallbackGame struct {
-	UserID             int64 `json:"user_id"`
-	Score              int   `json:"score"`
-	Force              bool  `json:"force,omitempty"`
-	DisableEditMessage bool  `json:"disable_edit_message,omitempty"`
-	ChatID             int   `json:"chat_id,omitempty"`
-	MessageID          int   `json:"message_id,omitempty"`
-	InlineMessageID    int   `json:"inline_message_id,omitempty"`
-}
+// CallbackGame https://core.telegram.org/bots/api#callbackgame
+type CallbackGame struct{}
 
 // ReplyKeyboardRemove https://core.telegram.org/bots/api#replykeyboardremove
 type ReplyKeyboardRemove struct {
@@ -22,7 +22,7 @@ type Sticker struct {
 	SetName          string       `json:"set_name,omitempty"`
 	PremiumAnimation *File        `json:"premium_animation,omitempty"`
 	MaskPosition     MaskPosition `json:"mask_position,omitempty"`
-	CustomEmojiID    string       `json:"custom_emoji_id"`
+	CustomEmojiID    string       `json:"custom_emoji_id,omitempty"`
 	NeedsRepainting  bool         `json:"needs_repainting,omitempty"`
 	FileSize         int          `json:"file_size,omitempty"`
 }
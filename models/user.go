@@ -10,7 +10,7 @@ type UserProfilePhotos struct {
 type User struct {
 	ID                      int64  `json:"id"`
 	IsBot                   bool   `json:"is_bot"`
-	FirstName               string `json:"first_name,omitempty"`
+	FirstName               string `json:"first_name"`
 	LastName                string `json:"last_name,omitempty"`
 	Username                string `json:"username,omitempty"`
 	LanguageCode            string `json:"language_code,omitempty"`
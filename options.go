@@ -75,7 +75,9 @@ func WithDebugHandler(handler DebugHandler) Option {
 	}
 }
 
-// WithHTTPClient allows to set custom http client
+// WithHTTPClient allows to set custom http client for API calls and polling.
+// pollTimeout is the long polling timeout of getUpdates, the client timeout must be greater.
+// Use WithPollingClient to set a separate client for polling
 func WithHTTPClient(pollTimeout time.Duration, client HttpClient) Option {
 	return func(b *Bot) {
 		b.pollTimeout = pollTimeout
@@ -145,3 +147,210 @@ func WithInitialOffset(offset int64) Option {
 		b.lastUpdateID = offset
 	}
 }
+
+// WithRequestEncoding allows to force request body encoding. By default, application/json is used
+// for requests without files to upload and multipart/form-data for the others
+func WithRequestEncoding(encoding RequestEncoding) Option {
+	return func(b *Bot) {
+		b.requestEncoding = encoding
+	}
+}
+
+// WithUploadProgressHandler allows to set handler for file uploads progress.
+// The handler is called for each file while it is being sent
+func WithUploadProgressHandler(handler UploadProgressHandler) Option {
+	return func(b *Bot) {
+		b.uploadProgressHandler = handler
+	}
+}
+
+// WithLocalMode allows to work with a local Bot API server, set its url with WithServerURL.
+// In local mode, files are downloaded directly from File.FilePath if it is an absolute local path
+func WithLocalMode() Option {
+	return func(b *Bot) {
+		b.setLocalMode(true)
+	}
+}
+
+// WithFileSizeLimits allows to check file sizes before uploading and downloading files.
+// Uploads larger than 50 MB and downloads larger than 20 MB fail with ErrorFileTooLarge without a request to the server.
+// With a local Bot API server, uploads are limited to 2000 MB and downloads are not limited
+func WithFileSizeLimits() Option {
+	return func(b *Bot) {
+		b.fileSizeLimits = true
+		b.setFileSizeLimits()
+	}
+}
+
+// WithFileIDCache allows to set cache for file_id values of uploaded files.
+// Files with the same content are sent as file_id instead of uploading them again.
+// Works for methods which send a single media file, like SendPhoto or SendDocument
+func WithFileIDCache(cache FileIDCache) Option {
+	return func(b *Bot) {
+		b.fileIDCache = cache
+	}
+}
+
+// WithWebhookMaxBodySize allows to set max size of webhook request body, by default 2 MB. 0 means no limit
+func WithWebhookMaxBodySize(size int64) Option {
+	return func(b *Bot) {
+		b.webhookMaxBodySize = size
+	}
+}
+
+// WithWebhookIPCheck allows to reject webhook requests not from Telegram subnets 149.154.160.0/20 and 91.108.4.0/22.
+// If trustForwardedFor is true, the last address of X-Forwarded-For header is checked instead of the remote address.
+// Use it only behind a proxy which sets this header
+func WithWebhookIPCheck(trustForwardedFor bool) Option {
+	return func(b *Bot) {
+		b.webhookIPCheck = true
+		b.webhookTrustForwardedFor = trustForwardedFor
+	}
+}
+
+// WithSyncWebhook allows to process updates in the webhook request instead of the updates channel.
+// Handlers can reply with a Bot API method call in the webhook response body using WebhookReply.
+// With WithDiskQueue, the update is written to the queue before processing, retries and the dead letter sink work as usual
+func WithSyncWebhook() Option {
+	return func(b *Bot) {
+		b.syncWebhook = true
+	}
+}
+
+// WithDedup allows to drop updates with already processed update_id before they reach handlers.
+// Use NewMemoryDedupStore for in-memory window of recent update ids, or your own persistent DedupStore
+func WithDedup(store DedupStore) Option {
+	return func(b *Bot) {
+		b.dedupStore = store
+	}
+}
+
+// WithDiskQueue allows to write incoming updates to the disk queue before they are acknowledged to Telegram.
+// Updates are removed from the queue after handlers return, not handled updates are delivered again after restart.
+// In polling mode, getUpdates continues from the last update id in the queue
+func WithDiskQueue(q *DiskQueue) Option {
+	return func(b *Bot) {
+		b.diskQueue = q
+	}
+}
+
+// WithRetryPolicy allows to call the handler again if it fails. A handler fails if it panics or calls FailUpdate,
+// see HandlerWithError. Retries are done in the same goroutine with exponential backoff
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(b *Bot) {
+		b.retryPolicy = policy
+	}
+}
+
+// WithDeadLetterSink allows to store updates which handlers failed after all attempts, see WithRetryPolicy.
+// Use NewFileDeadLetterSink for a JSONL file and bot.ReplayDeadLetters to process stored updates again
+func WithDeadLetterSink(sink DeadLetterSink) Option {
+	return func(b *Bot) {
+		b.deadLetterSink = sink
+	}
+}
+
+// WithConflictHandler allows to set handler for getUpdates conflict errors (409),
+// returned if another instance polls updates with the same token or the webhook is set
+func WithConflictHandler(handler ConflictHandler) Option {
+	return func(b *Bot) {
+		b.conflictHandler = handler
+	}
+}
+
+// WithStopPollingOnConflict allows to stop polling on getUpdates conflict error (409) instead of retrying.
+// Start returns after ctx is done
+func WithStopPollingOnConflict() Option {
+	return func(b *Bot) {
+		b.stopPollingOnConflict = true
+	}
+}
+
+// WithLeaderElection allows to run many bot instances with the same token, only the leader polls updates.
+// Other instances wait on standby and start polling after the leader stops. Use NewFileLeaderElector for instances on one host
+func WithLeaderElection(elector LeaderElector) Option {
+	return func(b *Bot) {
+		b.leaderElector = elector
+	}
+}
+
+// WithMetrics allows to collect the bot metrics: updates, handlers, API requests, polling errors and webhook rejections.
+// Serve them in Prometheus text format with Metrics.Handler
+func WithMetrics(m *Metrics) Option {
+	return func(b *Bot) {
+		b.metrics = m
+	}
+}
+
+// WithTracer allows to trace updates processing and Bot API requests.
+// Spans are started for each update, middleware, handler and API request. Requests made with the handler ctx are children of the update span
+func WithTracer(tracer Tracer) Option {
+	return func(b *Bot) {
+		b.tracer = tracer
+	}
+}
+
+// WithPollingClient allows to set custom http client for getUpdates long polling.
+// pollTimeout is the long polling timeout, the client timeout must be greater
+func WithPollingClient(pollTimeout time.Duration, client HttpClient) Option {
+	return func(b *Bot) {
+		b.pollTimeout = pollTimeout
+		b.pollClient = client
+	}
+}
+
+// WithRequestTimeout allows to set the timeout of API calls, by default 30 seconds.
+// getUpdates, uploads and downloads have own timeouts. 0 means no timeout
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(b *Bot) {
+		b.apiRequestTimeout = timeout
+	}
+}
+
+// WithUploadTimeout allows to set the timeout of API calls with files to upload, by default 10 minutes. 0 means no timeout
+func WithUploadTimeout(timeout time.Duration) Option {
+	return func(b *Bot) {
+		b.uploadTimeout = timeout
+	}
+}
+
+// WithDownloadTimeout allows to set the timeout of file downloads, by default 10 minutes. 0 means no timeout.
+// Downloads do not use the Timeout of *http.Client set by WithHTTPClient, other HttpClient implementations are used as is
+func WithDownloadTimeout(timeout time.Duration) Option {
+	return func(b *Bot) {
+		b.downloadTimeout = timeout
+	}
+}
+
+// WithMethodTimeout allows to set the timeout of the API method, like sendVideo. 0 means no timeout.
+// Overrides timeouts set by WithRequestTimeout and WithUploadTimeout
+func WithMethodTimeout(method string, timeout time.Duration) Option {
+	return func(b *Bot) {
+		b.methodTimeouts[method] = timeout
+	}
+}
+
+// WithConnectionPool allows to tune the connection pool of the default http client for API calls.
+// Ignored if the client is set by WithHTTPClient
+func WithConnectionPool(pool ConnectionPool) Option {
+	return func(b *Bot) {
+		b.connectionPool = pool
+	}
+}
+
+// WithPollingConnectionPool allows to tune the connection pool of the default http client for getUpdates.
+// Ignored if the client is set by WithHTTPClient or WithPollingClient
+func WithPollingConnectionPool(pool ConnectionPool) Option {
+	return func(b *Bot) {
+		b.pollingConnectionPool = pool
+	}
+}
+
+// WithParamsValidation allows to check params before the request is sent and return *ValidationError instead of a 400 response.
+// Checked are required chat_id, text and caption length, parse_mode together with entities, callback data size,
+// media group size and the number of inline query results. Params implementing ParamsValidator are checked by their Validate method too
+func WithParamsValidation() Option {
+	return func(b *Bot) {
+		b.validateParams = true
+	}
+}
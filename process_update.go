@@ -2,6 +2,8 @@ package bot
 
 import (
 	"context"
+	"sync/atomic"
+	"time"
 
 	"github.com/go-telegram/bot/models"
 )
@@ -19,16 +21,44 @@ func applyMiddlewares(h HandlerFunc, m ...Middleware) HandlerFunc {
 
 // ProcessUpdate allows you to process update
 func (b *Bot) ProcessUpdate(ctx context.Context, upd *models.Update) {
-	h := b.findHandler(upd)
+	if b.notAsyncHandlers {
+		b.processUpdate(ctx, upd)
+		return
+	}
 
-	r := applyMiddlewares(h, b.middlewares...)
+	go b.processUpdate(ctx, upd)
+}
 
-	if b.notAsyncHandlers {
-		r(ctx, b, upd)
+// processUpdate runs the matched handler with middlewares in the current goroutine
+func (b *Bot) processUpdate(ctx context.Context, upd *models.Update) {
+	if b.isDuplicate(ctx, upd) {
+		b.ackUpdate(upd)
 		return
 	}
 
-	go r(ctx, b, upd)
+	b.setLastUpdate()
+	b.metrics.updateReceived(upd)
+
+	ctx, span := b.startUpdateSpan(ctx, upd)
+
+	start := time.Now()
+	err := b.handleUpdateInFlight(ctx, upd)
+	b.metrics.observeHandler(time.Since(start), err)
+	if b.structuredLogger != nil {
+		b.structuredLogger.logUpdate(ctx, upd, time.Since(start), err)
+	}
+
+	span.End(err)
+
+	b.ackUpdate(upd)
+}
+
+// handleUpdateInFlight handles the update and counts it in handlersInFlight, also if the handler panics
+func (b *Bot) handleUpdateInFlight(ctx context.Context, upd *models.Update) error {
+	atomic.AddInt64(&b.handlersInFlight, 1)
+	defer atomic.AddInt64(&b.handlersInFlight, -1)
+
+	return b.handleUpdate(ctx, upd)
 }
 
 func (b *Bot) findHandler(upd *models.Update) HandlerFunc {
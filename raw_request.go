@@ -11,6 +11,9 @@ import (
 	"net/http"
 	"reflect"
 	"strings"
+	"time"
+
+	"github.com/go-telegram/bot/models"
 )
 
 type apiResponse struct {
@@ -24,27 +27,76 @@ type apiResponse struct {
 	} `json:"parameters,omitempty"`
 }
 
-func (b *Bot) rawRequest(ctx context.Context, method string, params any, dest any) error {
-	var httpBody io.Reader = http.NoBody
-	var contentType string
+// RequestEncoding defines how request params are encoded in the request body
+type RequestEncoding int
 
-	if params != nil && !reflect.ValueOf(params).IsNil() {
-		buf := bytes.NewBuffer(nil)
-		form := multipart.NewWriter(buf)
+const (
+	// RequestEncodingAuto uses application/json, or multipart/form-data if params contain files to upload
+	RequestEncodingAuto RequestEncoding = iota
+	// RequestEncodingMultipart always uses multipart/form-data
+	RequestEncodingMultipart
+	// RequestEncodingJSON always uses application/json. Requests with files to upload return an error
+	RequestEncodingJSON
+)
 
-		fieldsCount, errFormData := buildRequestForm(form, params)
-		if errFormData != nil {
-			return fmt.Errorf("error build request form for method %s, %w", method, errFormData)
+// UploadProgress describes the progress of a file upload
+type UploadProgress struct {
+	Method    string
+	FieldName string
+	Filename  string
+	// Uploaded is the number of bytes sent so far
+	Uploaded int64
+	// Total is the file size, 0 if the size is unknown
+	Total int64
+}
+
+func (b *Bot) rawRequest(ctx context.Context, method string, params any, dest any) error {
+	params = withTypedChatIDs(params)
+
+	if b.validateParams {
+		if err := validateParams(method, params); err != nil {
+			return err
 		}
+	}
 
-		errFormClose := form.Close()
-		if errFormClose != nil {
-			return fmt.Errorf("error form close for method %s, %w", method, errFormClose)
+	if b.fileIDCache != nil {
+		if upload, ok := findCacheableUpload(params, dest); ok {
+			return b.rawRequestFileIDCache(ctx, method, params, dest, upload)
 		}
+	}
 
-		if fieldsCount > 0 {
-			httpBody = buf
-			contentType = form.FormDataContentType()
+	return b.doRequest(ctx, method, params, dest)
+}
+
+// doRequest sends the request to the Bot API and decodes the result to dest
+func (b *Bot) doRequest(ctx context.Context, method string, params any, dest any) (err error) {
+	ctx, span := b.startAPIRequestSpan(ctx, method)
+	defer func() {
+		endAPIRequestSpan(span, err)
+	}()
+
+	if b.metrics != nil || b.structuredLogger != nil {
+		start := time.Now()
+		defer func() {
+			b.metrics.observeAPIRequest(method, time.Since(start), err)
+			if b.structuredLogger != nil {
+				b.structuredLogger.logRequest(ctx, method, params, time.Since(start), err)
+			}
+		}()
+	}
+
+	var httpBody io.Reader = http.NoBody
+	var contentType string
+
+	if !isNilParams(params) {
+		var errBody error
+		httpBody, contentType, errBody = b.buildRequestBody(method, params)
+		if errBody != nil {
+			return errBody
+		}
+		if c, ok := httpBody.(io.Closer); ok {
+			// stops streaming form-data if the client did not consume the whole body
+			defer func() { _ = c.Close() }()
 		}
 	}
 
@@ -56,10 +108,14 @@ func (b *Bot) rawRequest(ctx context.Context, method string, params any, dest an
 
 	if b.isDebug && strings.ToLower(method) != "getupdates" {
 		requestDebugData, _ := json.Marshal(params)
-		b.debugHandler("request url: %s, payload: %s", u, requestDebugData)
+		b.debugHandler("request url: %s, payload: %s", b.redactToken(u), requestDebugData)
 	}
 
-	req, errRequest := http.NewRequestWithContext(ctx, http.MethodPost, u, httpBody)
+	upload := strings.HasPrefix(contentType, "multipart/form-data") && hasUploads(params)
+	reqCtx, cancel := withTimeout(ctx, b.requestTimeout(method, upload))
+	defer cancel()
+
+	req, errRequest := http.NewRequestWithContext(reqCtx, http.MethodPost, u, httpBody)
 	if errRequest != nil {
 		return fmt.Errorf("error create request for method %s, %w", method, errRequest)
 	}
@@ -68,9 +124,9 @@ func (b *Bot) rawRequest(ctx context.Context, method string, params any, dest an
 		req.Header.Add("Content-Type", contentType)
 	}
 
-	resp, errDo := b.client.Do(req)
+	resp, errDo := b.httpClient(method).Do(req)
 	if errDo != nil {
-		return fmt.Errorf("error do request for method %s, %w", method, errDo)
+		return fmt.Errorf("error do request for method %s, %w", method, b.redactURLError(errDo))
 	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
@@ -123,7 +179,7 @@ func (b *Bot) rawRequest(ctx context.Context, method string, params any, dest an
 
 	if !bytes.Equal(r.Result, []byte("[]")) {
 		if b.isDebug {
-			b.debugHandler("response from '%s' with payload '%s'", u, body)
+			b.debugHandler("response from '%s' with payload '%s'", b.redactToken(u), body)
 		}
 	}
 
@@ -136,3 +192,203 @@ func (b *Bot) rawRequest(ctx context.Context, method string, params any, dest an
 
 	return nil
 }
+
+// buildRequestBody encodes params according to the bot request encoding
+// returns http.NoBody and empty content type if params have no fields to send
+// form-data with files to upload is streamed to the returned reader while the request is being sent
+func (b *Bot) buildRequestBody(method string, params any) (io.Reader, string, error) {
+	if reflect.ValueOf(params).Kind() == reflect.Map {
+		return buildRequestBodyMap(method, params)
+	}
+
+	readers := uploadReaders(params)
+	uploads := len(readers) > 0
+
+	if b.maxUploadSize > 0 {
+		for _, r := range readers {
+			if size := readerSize(r); size > b.maxUploadSize {
+				return nil, "", fmt.Errorf("%w, file size %d, max upload size %d", ErrorFileTooLarge, size, b.maxUploadSize)
+			}
+		}
+	}
+
+	switch b.requestEncoding {
+	case RequestEncodingJSON:
+		if uploads {
+			return nil, "", fmt.Errorf("error build request json for method %s, params contain files to upload", method)
+		}
+		return buildRequestBodyJSON(method, params)
+	case RequestEncodingAuto:
+		if !uploads {
+			return buildRequestBodyJSON(method, params)
+		}
+	}
+
+	if uploads {
+		body, contentType := b.streamRequestForm(method, params)
+		return body, contentType, nil
+	}
+
+	buf := bytes.NewBuffer(nil)
+	form := multipart.NewWriter(buf)
+
+	fieldsCount, errFormData := buildRequestForm(form, params, nil)
+	if errFormData != nil {
+		return nil, "", fmt.Errorf("error build request form for method %s, %w", method, errFormData)
+	}
+
+	errFormClose := form.Close()
+	if errFormClose != nil {
+		return nil, "", fmt.Errorf("error form close for method %s, %w", method, errFormClose)
+	}
+
+	if fieldsCount == 0 {
+		return http.NoBody, "", nil
+	}
+
+	return buf, form.FormDataContentType(), nil
+}
+
+// streamRequestForm writes form-data to a pipe in a separate goroutine, so file contents are not buffered in memory.
+// Writing stops when the returned reader is closed, e.g. by the http transport on ctx cancellation
+func (b *Bot) streamRequestForm(method string, params any) (io.ReadCloser, string) {
+	pr, pw := io.Pipe()
+	form := multipart.NewWriter(pw)
+
+	var progress fileProgressFunc
+	if b.uploadProgressHandler != nil {
+		progress = func(fieldName, filename string, uploaded, total int64) {
+			b.uploadProgressHandler(UploadProgress{
+				Method:    method,
+				FieldName: fieldName,
+				Filename:  filename,
+				Uploaded:  uploaded,
+				Total:     total,
+			})
+		}
+	}
+
+	go func() {
+		_, errFormData := buildRequestForm(form, params, progress)
+		if errFormData != nil {
+			pw.CloseWithError(fmt.Errorf("error build request form for method %s, %w", method, errFormData))
+			return
+		}
+		pw.CloseWithError(form.Close())
+	}()
+
+	return pr, form.FormDataContentType()
+}
+
+func buildRequestBodyJSON(method string, params any) (io.Reader, string, error) {
+	data, fieldsCount, errJSON := buildRequestJSON(params)
+	if errJSON != nil {
+		return nil, "", fmt.Errorf("error build request json for method %s, %w", method, errJSON)
+	}
+
+	if fieldsCount == 0 {
+		return http.NoBody, "", nil
+	}
+
+	return bytes.NewReader(data), "application/json", nil
+}
+
+// buildRequestBodyMap encodes map params as application/json
+func buildRequestBodyMap(method string, params any) (io.Reader, string, error) {
+	if reflect.ValueOf(params).Len() == 0 {
+		return http.NoBody, "", nil
+	}
+
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil, "", fmt.Errorf("error build request json for method %s, %w", method, err)
+	}
+
+	return bytes.NewReader(data), "application/json", nil
+}
+
+// RawRequest calls the Bot API method and returns the result decoded to T.
+// Use it for methods not wrapped by the library yet.
+// params is a params struct with json tags, like SendMessageParams, a pointer to it, a map with string keys, or nil.
+// Structs are encoded like params of built-in methods, including files to upload. Maps are sent as JSON.
+// Other params return an error.
+// The request goes through the same error mapping, file_id cache, metrics, tracing and logging as built-in methods,
+// the file_id cache works if T is *models.Message. The *models.Message result is nil if the method returns True,
+// like edit methods for inline messages
+func RawRequest[T any](ctx context.Context, b *Bot, method string, params any) (T, error) {
+	var result T
+
+	if params != nil {
+		v := reflect.ValueOf(params)
+		switch {
+		case v.Kind() == reflect.Struct:
+			p := reflect.New(v.Type())
+			p.Elem().Set(v)
+			params = p.Interface()
+		case v.Kind() == reflect.Ptr && v.Type().Elem().Kind() == reflect.Struct:
+		case v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String:
+		default:
+			return result, fmt.Errorf("error request method %s, unsupported params type %T, expected struct, pointer to struct or map with string keys", method, params)
+		}
+	}
+
+	if message, ok := any(&result).(**models.Message); ok {
+		// edit methods return True for inline messages, then the result is nil
+		dest := &messageOrTrue{}
+		err := b.rawRequest(ctx, method, params, dest)
+		*message = dest.message
+		return result, err
+	}
+
+	var dest any = &result
+	if t := reflect.TypeOf(result); t != nil && t.Kind() == reflect.Ptr {
+		// decode to the pointed value, so dest has the same type as in built-in methods
+		result = reflect.New(t.Elem()).Interface().(T)
+		dest = result
+	}
+
+	err := b.rawRequest(ctx, method, params, dest)
+
+	return result, err
+}
+
+// messageOrTrue is the result of methods, which return the message or True, like edit methods for inline messages
+type messageOrTrue struct {
+	message *models.Message
+}
+
+func (r *messageOrTrue) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("true")) {
+		r.message = nil
+		return nil
+	}
+
+	r.message = &models.Message{}
+	return json.Unmarshal(data, r.message)
+}
+
+// resultMessage returns the message of the request result, dest is *models.Message or *messageOrTrue
+func resultMessage(dest any) (*models.Message, bool) {
+	switch d := dest.(type) {
+	case *models.Message:
+		return d, true
+	case *messageOrTrue:
+		return d.message, true
+	}
+	return nil, false
+}
+
+// isNilParams reports whether params is nil or a nil pointer or map
+func isNilParams(params any) bool {
+	if params == nil {
+		return true
+	}
+
+	v := reflect.ValueOf(params)
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Interface, reflect.Slice:
+		return v.IsNil()
+	}
+
+	return false
+}
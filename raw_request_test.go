@@ -2,10 +2,15 @@ package bot
 
 import (
 	"context"
+	"errors"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/go-telegram/bot/models"
 )
 
 type clientMock struct {
@@ -55,3 +60,177 @@ func Test_rawRequest_url_testEnv(t *testing.T) {
 		t.Fatalf("unexpected requestURI: %s", cm.requestURI)
 	}
 }
+
+func Test_rawRequest_streamUpload(t *testing.T) {
+	content := strings.Repeat("x", 1<<20)
+
+	s := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		f, _, err := req.FormFile("photo")
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		data, _ := io.ReadAll(f)
+		if string(data) != content {
+			t.Errorf("unexpected file content length %d", len(data))
+		}
+		_, _ = rw.Write([]byte(`{"ok":true,"result":{}}`))
+	}))
+	defer s.Close()
+
+	var progress []UploadProgress
+	b := &Bot{
+		url:    s.URL,
+		token:  "XXX",
+		client: http.DefaultClient,
+		uploadProgressHandler: func(p UploadProgress) {
+			progress = append(progress, p)
+		},
+	}
+
+	_, err := b.SendPhoto(context.Background(), &SendPhotoParams{
+		ChatID: 1,
+		Photo:  &models.InputFileUpload{Filename: "photo.png", Data: strings.NewReader(content)},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(progress) == 0 {
+		t.Fatal("expected upload progress")
+	}
+	last := progress[len(progress)-1]
+	if last.Method != "sendPhoto" || last.FieldName != "photo" || last.Filename != "photo.png" {
+		t.Fatalf("unexpected progress %+v", last)
+	}
+	if last.Uploaded != int64(len(content)) || last.Total != int64(len(content)) {
+		t.Fatalf("unexpected progress %+v", last)
+	}
+}
+
+type endlessReader struct{}
+
+func (endlessReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 'x'
+	}
+	return len(p), nil
+}
+
+func Test_rawRequest_streamUpload_ctxCancel(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = io.Copy(io.Discard, req.Body)
+	}))
+	defer s.Close()
+
+	b := &Bot{
+		url:    s.URL,
+		token:  "XXX",
+		client: http.DefaultClient,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*200)
+	defer cancel()
+
+	_, err := b.SendDocument(ctx, &SendDocumentParams{
+		ChatID:   1,
+		Document: &models.InputFileUpload{Filename: "endless.txt", Data: endlessReader{}},
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded error, got %v", err)
+	}
+}
+
+func TestRawRequest(t *testing.T) {
+	var contentType, body string
+
+	s := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		data, _ := io.ReadAll(req.Body)
+		contentType, body = req.Header.Get("Content-Type"), string(data)
+
+		switch req.URL.Path {
+		case "/botXXX/newMethod":
+			_, _ = rw.Write([]byte(`{"ok":true,"result":{"id":5,"title":"title"}}`))
+		case "/botXXX/newBoolMethod":
+			_, _ = rw.Write([]byte(`{"ok":true,"result":true}`))
+		default:
+			_, _ = rw.Write([]byte(`{"ok":false,"error_code":404,"description":"Not Found: method not found"}`))
+		}
+	}))
+	defer s.Close()
+
+	b, err := New("XXX", WithServerURL(s.URL), WithSkipGetMe())
+	assertNoErr(t, err)
+
+	type newMethodParams struct {
+		ChatID any    `json:"chat_id"`
+		Title  string `json:"title,omitempty"`
+	}
+	type newMethodResult struct {
+		ID    int64  `json:"id"`
+		Title string `json:"title"`
+	}
+
+	ctx := context.Background()
+
+	res, err := RawRequest[*newMethodResult](ctx, b, "newMethod", &newMethodParams{ChatID: 1, Title: "title"})
+	assertNoErr(t, err)
+	assertEqualInt(t, int(res.ID), 5)
+	assertEqualString(t, res.Title, "title")
+	assertEqualString(t, contentType, "application/json")
+	assertEqualString(t, body, `{"chat_id":1,"title":"title"}`)
+
+	_, err = RawRequest[newMethodResult](ctx, b, "newMethod", newMethodParams{ChatID: "@channel"})
+	assertNoErr(t, err)
+	assertEqualString(t, body, `{"chat_id":"@channel"}`)
+
+	ok, err := RawRequest[bool](ctx, b, "newBoolMethod", map[string]any{"chat_id": 1})
+	assertNoErr(t, err)
+	assertTrue(t, ok)
+	assertEqualString(t, body, `{"chat_id":1}`)
+
+	_, err = RawRequest[bool](ctx, b, "newBoolMethod", nil)
+	assertNoErr(t, err)
+	assertEqualString(t, body, "")
+
+	msg, err := RawRequest[*models.Message](ctx, b, "newBoolMethod", nil)
+	assertNoErr(t, err)
+	assertTrue(t, msg == nil)
+
+	_, err = RawRequest[bool](ctx, b, "unknownMethod", nil)
+	assertTrue(t, errors.Is(err, ErrorNotFound))
+}
+
+func TestRawRequest_UnsupportedParams(t *testing.T) {
+	b, err := New("XXX", WithSkipGetMe(), WithHTTPClient(0, &httpClient{t: t, resp: `true`}))
+	assertNoErr(t, err)
+
+	for _, params := range []any{[]int{1}, "foo", 42, map[int]string{1: "foo"}} {
+		_, err = RawRequest[bool](context.Background(), b, "newMethod", params)
+		if err == nil {
+			t.Fatalf("expected error for params %T", params)
+		}
+	}
+
+	var nilParams *SendMessageParams
+	_, err = RawRequest[bool](context.Background(), b, "newMethod", nilParams)
+	assertNoErr(t, err)
+}
+
+func TestRawRequest_FileIDCache(t *testing.T) {
+	m := newFileIDServerMock()
+	defer m.s.Close()
+
+	b := &Bot{url: m.s.URL, token: "XXX", client: http.DefaultClient, fileIDCache: NewMemoryFileIDCache()}
+
+	for i := 0; i < 2; i++ {
+		msg, errRequest := RawRequest[*models.Message](context.Background(), b, "sendPhoto", &SendPhotoParams{
+			ChatID: 1,
+			Photo:  &models.InputFileUpload{Filename: "foo.png", Data: strings.NewReader("foo")},
+		})
+		assertNoErr(t, errRequest)
+		assertEqualString(t, msg.Photo[len(msg.Photo)-1].FileID, "big")
+	}
+
+	assertEqualInt(t, m.uploads, 1)
+}
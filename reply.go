@@ -0,0 +1,166 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/go-telegram/bot/models"
+)
+
+var errNoReplyTarget = errors.New("error reply to update, update has no chat")
+
+// ReplyTarget is the place to reply to an update: the chat, the forum topic, the business connection and the message
+type ReplyTarget struct {
+	ChatID int64
+	// MessageThreadID is set for messages in forum topics
+	MessageThreadID int
+	// BusinessConnectionID is set for messages received on behalf of a business account
+	BusinessConnectionID string
+	// MessageID is the message to reply to, 0 if the update has no message
+	MessageID int
+}
+
+// ReplyTargetFromUpdate returns the place to reply to the update.
+// Works for messages, edited messages, channel posts, business messages, callback queries and chat member updates.
+// Returns false if the update has no chat, like inline queries and callback queries from inline messages
+func ReplyTargetFromUpdate(upd *models.Update) (ReplyTarget, bool) {
+	if upd == nil {
+		return ReplyTarget{}, false
+	}
+
+	if m := updateMessage(upd); m != nil {
+		return replyTargetFromMessage(m), true
+	}
+
+	if upd.CallbackQuery != nil && upd.CallbackQuery.Message.InaccessibleMessage != nil {
+		return ReplyTarget{
+			ChatID:    upd.CallbackQuery.Message.InaccessibleMessage.Chat.ID,
+			MessageID: upd.CallbackQuery.Message.InaccessibleMessage.MessageID,
+		}, true
+	}
+
+	if chatID, ok := updateChatID(upd); ok {
+		return ReplyTarget{ChatID: chatID}, true
+	}
+
+	return ReplyTarget{}, false
+}
+
+func replyTargetFromMessage(m *models.Message) ReplyTarget {
+	target := ReplyTarget{
+		ChatID:               m.Chat.ID,
+		BusinessConnectionID: m.BusinessConnectionID,
+		MessageID:            m.ID,
+	}
+	// message_thread_id of replies in groups without topics is not accepted by send methods
+	if m.IsTopicMessage {
+		target.MessageThreadID = m.MessageThreadID
+	}
+
+	return target
+}
+
+// updateMessage returns the message of the update, including the message with the callback button
+func updateMessage(upd *models.Update) *models.Message {
+	for _, m := range []*models.Message{upd.Message, upd.EditedMessage, upd.ChannelPost, upd.EditedChannelPost, upd.BusinessMessage, upd.EditedBusinessMessage} {
+		if m != nil {
+			return m
+		}
+	}
+
+	if upd.CallbackQuery != nil && upd.CallbackQuery.Message.Message != nil {
+		return upd.CallbackQuery.Message.Message
+	}
+
+	return nil
+}
+
+// WithReplyTarget sets ChatID, MessageThreadID and BusinessConnectionID of send method params from the target
+// and returns params. Fields already set in params are not changed:
+//
+//	b.SendPhoto(ctx, bot.WithReplyTarget(target, &bot.SendPhotoParams{Photo: photo}))
+func WithReplyTarget[P any](target ReplyTarget, params P) P {
+	applyReplyTarget(target, params, false)
+	return params
+}
+
+// WithReplyTo is like WithReplyTarget, and also sets ReplyParameters to reply to the target message.
+// If params have ReplyParameters, for example with a quote, only the message id is set
+func WithReplyTo[P any](target ReplyTarget, params P) P {
+	applyReplyTarget(target, params, true)
+	return params
+}
+
+// Answer sends the message to the chat, the forum topic and the business connection of the update
+func (b *Bot) Answer(ctx context.Context, update *models.Update, params *SendMessageParams) (*models.Message, error) {
+	target, ok := ReplyTargetFromUpdate(update)
+	if !ok {
+		return nil, errNoReplyTarget
+	}
+
+	return b.SendMessage(ctx, WithReplyTarget(target, params))
+}
+
+// Reply sends the message as a reply to the message of the update, in the same chat, forum topic and business connection.
+// Set params.ReplyParameters to quote a part of the message
+func (b *Bot) Reply(ctx context.Context, update *models.Update, params *SendMessageParams) (*models.Message, error) {
+	target, ok := ReplyTargetFromUpdate(update)
+	if !ok {
+		return nil, errNoReplyTarget
+	}
+
+	return b.SendMessage(ctx, WithReplyTo(target, params))
+}
+
+// applyReplyTarget sets zero chat_id, message_thread_id, business_connection_id and reply_parameters fields of params.
+// The chat is set to the typed Chat field, if params have it
+func applyReplyTarget(target ReplyTarget, params any, reply bool) {
+	v := reflect.ValueOf(params)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return
+	}
+	v = v.Elem()
+	var chat *models.ChatID
+	if f := v.FieldByName("Chat"); f.IsValid() {
+		chat, _ = f.Addr().Interface().(*models.ChatID)
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		name, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("json"), ",")
+
+		if name == "reply_parameters" {
+			rp, ok := f.Interface().(*models.ReplyParameters)
+			if !ok || !reply || target.MessageID == 0 {
+				continue
+			}
+			if rp == nil {
+				f.Set(reflect.ValueOf(&models.ReplyParameters{MessageID: target.MessageID}))
+			} else if rp.MessageID == 0 {
+				copied := *rp
+				copied.MessageID = target.MessageID
+				f.Set(reflect.ValueOf(&copied))
+			}
+			continue
+		}
+
+		if !f.IsZero() {
+			continue
+		}
+
+		switch {
+		case name == "chat_id" && chat != nil && target.ChatID != 0:
+			if chat.IsZero() {
+				*chat = models.NewChatID(target.ChatID)
+			}
+		case name == "chat_id" && f.Kind() == reflect.Interface && target.ChatID != 0:
+			f.Set(reflect.ValueOf(target.ChatID))
+		case name == "message_thread_id" && f.Kind() == reflect.Int:
+			f.SetInt(int64(target.MessageThreadID))
+		case name == "business_connection_id" && f.Kind() == reflect.String:
+			f.SetString(target.BusinessConnectionID)
+		}
+	}
+}
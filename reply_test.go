@@ -0,0 +1,114 @@
+package bot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestReplyTargetFromUpdate(t *testing.T) {
+	topicMessage := &models.Message{ID: 5, Chat: models.Chat{ID: 1}, MessageThreadID: 7, IsTopicMessage: true}
+
+	tests := []struct {
+		name   string
+		update *models.Update
+		target ReplyTarget
+		ok     bool
+	}{
+		{
+			name:   "topic message",
+			update: &models.Update{Message: topicMessage},
+			target: ReplyTarget{ChatID: 1, MessageThreadID: 7, MessageID: 5},
+			ok:     true,
+		},
+		{
+			name:   "reply thread without topics",
+			update: &models.Update{Message: &models.Message{ID: 5, Chat: models.Chat{ID: 1}, MessageThreadID: 3}},
+			target: ReplyTarget{ChatID: 1, MessageID: 5},
+			ok:     true,
+		},
+		{
+			name:   "edited business message",
+			update: &models.Update{EditedBusinessMessage: &models.Message{ID: 5, Chat: models.Chat{ID: 1}, BusinessConnectionID: "bc"}},
+			target: ReplyTarget{ChatID: 1, BusinessConnectionID: "bc", MessageID: 5},
+			ok:     true,
+		},
+		{
+			name:   "callback query",
+			update: &models.Update{CallbackQuery: &models.CallbackQuery{Message: models.MaybeInaccessibleMessage{Message: topicMessage}}},
+			target: ReplyTarget{ChatID: 1, MessageThreadID: 7, MessageID: 5},
+			ok:     true,
+		},
+		{
+			name: "callback query of inaccessible message",
+			update: &models.Update{CallbackQuery: &models.CallbackQuery{Message: models.MaybeInaccessibleMessage{
+				InaccessibleMessage: &models.InaccessibleMessage{Chat: models.Chat{ID: 2}, MessageID: 6},
+			}}},
+			target: ReplyTarget{ChatID: 2, MessageID: 6},
+			ok:     true,
+		},
+		{
+			name:   "chat member",
+			update: &models.Update{MyChatMember: &models.ChatMemberUpdated{Chat: models.Chat{ID: 3}}},
+			target: ReplyTarget{ChatID: 3},
+			ok:     true,
+		},
+		{
+			name:   "inline callback query",
+			update: &models.Update{CallbackQuery: &models.CallbackQuery{InlineMessageID: "inline"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, ok := ReplyTargetFromUpdate(tt.update)
+			assertTrue(t, ok == tt.ok)
+			assertTrue(t, target == tt.target)
+		})
+	}
+}
+
+func TestWithReplyTo(t *testing.T) {
+	target := ReplyTarget{ChatID: 1, MessageThreadID: 7, BusinessConnectionID: "bc", MessageID: 5}
+
+	shared := &models.ReplyParameters{Quote: "quote"}
+	params := WithReplyTo(target, &SendPhotoParams{ReplyParameters: shared})
+	assertTrue(t, params.Chat == models.NewChatID(1) && params.ChatID == nil)
+	assertEqualInt(t, params.MessageThreadID, 7)
+	assertEqualString(t, params.BusinessConnectionID, "bc")
+	assertEqualInt(t, params.ReplyParameters.MessageID, 5)
+	assertEqualString(t, params.ReplyParameters.Quote, "quote")
+	assertEqualInt(t, shared.MessageID, 0)
+
+	params = WithReplyTarget(target, &SendPhotoParams{ChatID: "@channel"})
+	assertTrue(t, params.ChatID == "@channel" && params.Chat.IsZero())
+	assertTrue(t, params.ReplyParameters == nil)
+
+	params = WithReplyTarget(target, &SendPhotoParams{Chat: models.NewChatUsername("channel")})
+	assertTrue(t, params.Chat == models.NewChatUsername("channel"))
+}
+
+func TestBot_Reply(t *testing.T) {
+	ctx := context.Background()
+	update := &models.Update{Message: &models.Message{ID: 5, Chat: models.Chat{ID: 1}, MessageThreadID: 7, IsTopicMessage: true}}
+
+	client := &httpClient{t: t, resp: `{"message_id":6,"chat":{"id":1}}`, reqFields: map[string]string{
+		"chat_id":           "1",
+		"message_thread_id": "7",
+		"text":              "text",
+		"reply_parameters":  `{"message_id":5}`,
+	}}
+	b, err := New("xxx", WithSkipGetMe(), WithHTTPClient(0, client))
+	assertNoErr(t, err)
+
+	_, err = b.Reply(ctx, update, &SendMessageParams{Text: "text"})
+	assertNoErr(t, err)
+
+	delete(client.reqFields, "reply_parameters")
+	_, err = b.Answer(ctx, update, &SendMessageParams{Text: "text"})
+	assertNoErr(t, err)
+
+	_, err = b.Reply(ctx, &models.Update{InlineQuery: &models.InlineQuery{}}, &SendMessageParams{Text: "text"})
+	assertTrue(t, err != nil)
+}
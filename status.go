@@ -0,0 +1,176 @@
+package bot
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// livenessPollGrace is added to the poll timeout to detect stuck polling
+const livenessPollGrace = time.Second * 30
+
+// Status is a snapshot of the bot runtime state
+type Status struct {
+	// Running is true while Start or StartWebhook is running
+	Running bool `json:"running"`
+	// Polling is true while the bot polls updates with getUpdates
+	Polling bool `json:"polling"`
+	// LastPollAt is the time of the last successful getUpdates request
+	LastPollAt time.Time `json:"last_poll_at"`
+	// LastUpdateAt is the time the last update was passed to handlers
+	LastUpdateAt time.Time `json:"last_update_at"`
+
+	UpdatesQueueLen  int   `json:"updates_queue_len"`
+	UpdatesQueueCap  int   `json:"updates_queue_cap"`
+	HandlersInFlight int64 `json:"handlers_in_flight"`
+	DuplicateUpdates int64 `json:"duplicate_updates"`
+
+	LastError   string    `json:"last_error,omitempty"`
+	LastErrorAt time.Time `json:"last_error_at"`
+}
+
+// HealthCheck is the response body of LivenessHandler and ReadinessHandler
+type HealthCheck struct {
+	OK     bool   `json:"ok"`
+	Reason string `json:"reason,omitempty"`
+	Status Status `json:"status"`
+}
+
+type botStatus struct {
+	mx           sync.Mutex
+	running      int
+	polling      bool
+	pollingSince time.Time
+	lastPollAt   time.Time
+	lastUpdateAt time.Time
+	lastError    string
+	lastErrorAt  time.Time
+}
+
+// Status returns the snapshot of the bot runtime state
+func (b *Bot) Status() Status {
+	b.status.mx.Lock()
+	s := Status{
+		Running:      b.status.running > 0,
+		Polling:      b.status.polling,
+		LastPollAt:   b.status.lastPollAt,
+		LastUpdateAt: b.status.lastUpdateAt,
+		LastError:    b.status.lastError,
+		LastErrorAt:  b.status.lastErrorAt,
+	}
+	b.status.mx.Unlock()
+
+	s.UpdatesQueueLen = len(b.updates)
+	s.UpdatesQueueCap = cap(b.updates)
+	s.HandlersInFlight = atomic.LoadInt64(&b.handlersInFlight)
+	s.DuplicateUpdates = b.DuplicateUpdates()
+
+	return s
+}
+
+// LivenessHandler returns http handler, which responds with 200 if the bot is alive and 503 if polling is stuck:
+// no successful getUpdates request for the poll timeout and 30 seconds.
+// Use it for liveness probes to restart a stuck bot
+func (b *Bot) LivenessHandler() http.Handler {
+	return b.healthHandler(b.checkLiveness)
+}
+
+// ReadinessHandler returns http handler, which responds with 200 if the bot is started and the updates channel is not full, and 503 otherwise
+func (b *Bot) ReadinessHandler() http.Handler {
+	return b.healthHandler(b.checkReadiness)
+}
+
+func (b *Bot) healthHandler(check func(s Status) string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		s := b.Status()
+		reason := check(s)
+
+		w.Header().Set("Content-Type", "application/json")
+		if reason != "" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+
+		if err := json.NewEncoder(w).Encode(HealthCheck{OK: reason == "", Reason: reason, Status: s}); err != nil {
+			b.error("error write health check response, %w", err)
+		}
+	})
+}
+
+// checkLiveness returns the reason the bot is not alive or empty string
+func (b *Bot) checkLiveness(_ Status) string {
+	b.status.mx.Lock()
+	defer b.status.mx.Unlock()
+
+	if !b.status.polling {
+		return ""
+	}
+
+	last := b.status.lastPollAt
+	if last.Before(b.status.pollingSince) {
+		last = b.status.pollingSince
+	}
+
+	if time.Since(last) > b.pollTimeout+livenessPollGrace {
+		return "no successful getUpdates requests since " + last.Format(time.RFC3339)
+	}
+
+	return ""
+}
+
+// checkReadiness returns the reason the bot is not ready or empty string
+func (b *Bot) checkReadiness(s Status) string {
+	if !s.Running {
+		return "bot is not started"
+	}
+
+	if s.UpdatesQueueCap > 0 && s.UpdatesQueueLen >= s.UpdatesQueueCap {
+		return "updates channel is full"
+	}
+
+	return ""
+}
+
+func (b *Bot) setRunning(running bool) {
+	b.status.mx.Lock()
+	defer b.status.mx.Unlock()
+
+	if running {
+		b.status.running++
+		return
+	}
+	b.status.running--
+}
+
+func (b *Bot) setPolling(polling bool) {
+	b.status.mx.Lock()
+	defer b.status.mx.Unlock()
+
+	b.status.polling = polling
+	if polling {
+		b.status.pollingSince = time.Now()
+	}
+}
+
+func (b *Bot) setLastPoll() {
+	b.status.mx.Lock()
+	defer b.status.mx.Unlock()
+
+	b.status.lastPollAt = time.Now()
+}
+
+func (b *Bot) setLastUpdate() {
+	b.status.mx.Lock()
+	defer b.status.mx.Unlock()
+
+	b.status.lastUpdateAt = time.Now()
+}
+
+func (b *Bot) setLastError(err error) {
+	b.status.mx.Lock()
+	defer b.status.mx.Unlock()
+
+	b.status.lastError = err.Error()
+	b.status.lastErrorAt = time.Now()
+}
@@ -0,0 +1,114 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestBot_Status(t *testing.T) {
+	block := make(chan struct{})
+	started := make(chan struct{})
+
+	b := &Bot{
+		defaultHandlerFunc: func(ctx context.Context, bot *Bot, update *models.Update) {
+			close(started)
+			<-block
+		},
+		errorsHandler: func(err error) {},
+		updates:       make(chan *models.Update, 2),
+	}
+
+	b.updates <- &models.Update{}
+	b.error("some error %d", 1)
+
+	go b.processUpdate(context.Background(), &models.Update{ID: 1})
+	<-started
+
+	s := b.Status()
+	assertTrue(t, !s.Running)
+	assertEqualInt(t, s.UpdatesQueueLen, 1)
+	assertEqualInt(t, s.UpdatesQueueCap, 2)
+	assertEqualInt(t, int(s.HandlersInFlight), 1)
+	assertEqualString(t, s.LastError, "some error 1")
+	assertTrue(t, !s.LastErrorAt.IsZero())
+	assertTrue(t, !s.LastUpdateAt.IsZero())
+
+	close(block)
+}
+
+func TestBot_ReadinessHandler(t *testing.T) {
+	b := &Bot{
+		errorsHandler: func(err error) {},
+		updates:       make(chan *models.Update, 1),
+	}
+
+	check := func(expectCode int) HealthCheck {
+		rec := httptest.NewRecorder()
+		b.ReadinessHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+		assertEqualInt(t, rec.Code, expectCode)
+
+		res := HealthCheck{}
+		assertNoErr(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		return res
+	}
+
+	res := check(http.StatusServiceUnavailable)
+	assertEqualString(t, res.Reason, "bot is not started")
+
+	b.setRunning(true)
+	res = check(http.StatusOK)
+	assertTrue(t, res.OK)
+
+	b.updates <- &models.Update{}
+	res = check(http.StatusServiceUnavailable)
+	assertEqualString(t, res.Reason, "updates channel is full")
+}
+
+func TestBot_LivenessHandler(t *testing.T) {
+	b := &Bot{
+		errorsHandler: func(err error) {},
+		pollTimeout:   time.Second,
+	}
+
+	check := func() int {
+		rec := httptest.NewRecorder()
+		b.LivenessHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/live", nil))
+		return rec.Code
+	}
+
+	assertEqualInt(t, check(), http.StatusOK)
+
+	b.setPolling(true)
+	assertEqualInt(t, check(), http.StatusOK)
+
+	b.status.pollingSince = time.Now().Add(-time.Minute)
+	assertEqualInt(t, check(), http.StatusServiceUnavailable)
+
+	b.setLastPoll()
+	assertEqualInt(t, check(), http.StatusOK)
+
+	b.setLastError(errors.New("error"))
+	assertEqualString(t, b.Status().LastError, "error")
+}
+
+func TestBot_Status_HandlerPanic(t *testing.T) {
+	b := &Bot{
+		defaultHandlerFunc: func(ctx context.Context, bot *Bot, update *models.Update) {
+			panic("foo")
+		},
+	}
+
+	func() {
+		defer func() { _ = recover() }()
+		b.processUpdate(context.Background(), &models.Update{ID: 1})
+	}()
+
+	assertEqualInt(t, int(b.Status().HandlersInFlight), 0)
+}
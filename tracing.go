@@ -0,0 +1,111 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-telegram/bot/models"
+)
+
+// Tracer starts spans around updates, middlewares, handlers and Bot API requests, see WithTracer.
+// Implement it with an adapter for your tracing library
+type Tracer interface {
+	// Start starts the span. The returned ctx carries the span, spans started with this ctx are its children
+	Start(ctx context.Context, name string, attrs ...SpanAttribute) (context.Context, Span)
+}
+
+// Span is a span started by Tracer
+type Span interface {
+	SetAttributes(attrs ...SpanAttribute)
+	// End ends the span, err is nil on success
+	End(err error)
+}
+
+// SpanAttribute is a key-value attribute of a span
+type SpanAttribute struct {
+	Key   string
+	Value any
+}
+
+// span names and attribute keys
+const (
+	spanUpdate     = "telegram.update"
+	spanMiddleware = "telegram.middleware"
+	spanHandler    = "telegram.handler"
+	spanAPIRequest = "telegram.api."
+
+	SpanAttributeUpdateID        = "telegram.update_id"
+	SpanAttributeUpdateType      = "telegram.update_type"
+	SpanAttributeMiddlewareIndex = "telegram.middleware_index"
+	SpanAttributeMethod          = "telegram.method"
+	SpanAttributeErrorCode       = "telegram.error_code"
+)
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(...SpanAttribute) {}
+func (noopSpan) End(error)                      {}
+
+// startSpan starts the span with the bot tracer, if any
+func (b *Bot) startSpan(ctx context.Context, name string, attrs ...SpanAttribute) (context.Context, Span) {
+	if b.tracer == nil {
+		return ctx, noopSpan{}
+	}
+	return b.tracer.Start(ctx, name, attrs...)
+}
+
+// startUpdateSpan starts the span of the update processing
+func (b *Bot) startUpdateSpan(ctx context.Context, upd *models.Update) (context.Context, Span) {
+	if b.tracer == nil {
+		return ctx, noopSpan{}
+	}
+	return b.tracer.Start(ctx, spanUpdate,
+		SpanAttribute{Key: SpanAttributeUpdateID, Value: upd.ID},
+		SpanAttribute{Key: SpanAttributeUpdateType, Value: updateType(upd)},
+	)
+}
+
+// startAPIRequestSpan starts the span of the Bot API request
+func (b *Bot) startAPIRequestSpan(ctx context.Context, method string) (context.Context, Span) {
+	return b.startSpan(ctx, spanAPIRequest+method, SpanAttribute{Key: SpanAttributeMethod, Value: method})
+}
+
+// endAPIRequestSpan ends the span of the Bot API request with the error code attribute on failure
+func endAPIRequestSpan(span Span, err error) {
+	if err != nil {
+		span.SetAttributes(SpanAttribute{Key: SpanAttributeErrorCode, Value: apiErrorCode(err)})
+	}
+	span.End(err)
+}
+
+// applyTracedMiddlewares applies middlewares like applyMiddlewares, wrapping each middleware and the handler in a span
+func (b *Bot) applyTracedMiddlewares(h HandlerFunc, m ...Middleware) HandlerFunc {
+	wrapped := b.traceHandler(h, spanHandler)
+	for i := len(m) - 1; i >= 0; i-- {
+		wrapped = b.traceHandler(m[i](wrapped), spanMiddleware, SpanAttribute{Key: SpanAttributeMiddlewareIndex, Value: i})
+	}
+	return wrapped
+}
+
+func (b *Bot) traceHandler(h HandlerFunc, name string, attrs ...SpanAttribute) HandlerFunc {
+	return func(ctx context.Context, bot *Bot, update *models.Update) {
+		spanCtx, span := b.tracer.Start(ctx, name, attrs...)
+
+		var err error
+		defer func() {
+			if r := recover(); r != nil {
+				span.End(fmt.Errorf("handler panic: %v", r))
+				panic(r)
+			}
+			span.End(err)
+		}()
+
+		h(spanCtx, bot, update)
+
+		if f, ok := ctx.Value(handlerFailureKey{}).(*handlerFailure); ok {
+			f.mx.Lock()
+			err = f.err
+			f.mx.Unlock()
+		}
+	}
+}
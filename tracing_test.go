@@ -0,0 +1,114 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/go-telegram/bot/models"
+)
+
+type testSpan struct {
+	tracer *testTracer
+	name   string
+	parent string
+	attrs  map[string]any
+	err    error
+	ended  bool
+}
+
+func (s *testSpan) SetAttributes(attrs ...SpanAttribute) {
+	s.tracer.mx.Lock()
+	defer s.tracer.mx.Unlock()
+
+	for _, a := range attrs {
+		s.attrs[a.Key] = a.Value
+	}
+}
+
+func (s *testSpan) End(err error) {
+	s.tracer.mx.Lock()
+	defer s.tracer.mx.Unlock()
+
+	s.err = err
+	s.ended = true
+}
+
+type testSpanKey struct{}
+
+type testTracer struct {
+	mx    sync.Mutex
+	spans []*testSpan
+}
+
+func (t *testTracer) Start(ctx context.Context, name string, attrs ...SpanAttribute) (context.Context, Span) {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	s := &testSpan{tracer: t, name: name, attrs: map[string]any{}}
+	if parent, ok := ctx.Value(testSpanKey{}).(*testSpan); ok {
+		s.parent = parent.name
+	}
+	for _, a := range attrs {
+		s.attrs[a.Key] = a.Value
+	}
+	t.spans = append(t.spans, s)
+
+	return context.WithValue(ctx, testSpanKey{}, s), s
+}
+
+func TestBot_Tracer(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{"ok":false,"error_code":400,"description":"Bad Request: chat not found"}`))
+	}))
+	defer s.Close()
+
+	tracer := &testTracer{}
+
+	middleware := func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, bot *Bot, update *models.Update) {
+			next(ctx, bot, update)
+		}
+	}
+
+	b, err := New("XXX", WithServerURL(s.URL), WithSkipGetMe(), WithTracer(tracer), WithMiddlewares(middleware),
+		WithErrorsHandler(func(err error) {}),
+		WithDefaultHandler(HandlerWithError(func(ctx context.Context, bot *Bot, update *models.Update) error {
+			_, errSend := bot.SendMessage(ctx, &SendMessageParams{ChatID: 1, Text: "text"})
+			return errSend
+		})))
+	assertNoErr(t, err)
+
+	b.processUpdate(context.Background(), &models.Update{ID: 7, Message: &models.Message{}})
+
+	tracer.mx.Lock()
+	defer tracer.mx.Unlock()
+
+	assertEqualInt(t, len(tracer.spans), 4)
+
+	update, mw, handler, request := tracer.spans[0], tracer.spans[1], tracer.spans[2], tracer.spans[3]
+
+	assertEqualString(t, update.name, "telegram.update")
+	assertEqualString(t, update.parent, "")
+	assertEqualInt(t, int(update.attrs[SpanAttributeUpdateID].(int64)), 7)
+	assertEqualString(t, update.attrs[SpanAttributeUpdateType].(string), "message")
+
+	assertEqualString(t, mw.name, "telegram.middleware")
+	assertEqualString(t, mw.parent, "telegram.update")
+	assertEqualInt(t, mw.attrs[SpanAttributeMiddlewareIndex].(int), 0)
+
+	assertEqualString(t, handler.name, "telegram.handler")
+	assertEqualString(t, handler.parent, "telegram.middleware")
+
+	assertEqualString(t, request.name, "telegram.api.sendMessage")
+	assertEqualString(t, request.parent, "telegram.handler")
+	assertEqualString(t, request.attrs[SpanAttributeErrorCode].(string), "400")
+
+	for _, span := range tracer.spans {
+		assertTrue(t, span.ended)
+		assertTrue(t, errors.Is(span.err, ErrorBadRequest))
+	}
+}
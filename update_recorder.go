@@ -0,0 +1,334 @@
+package bot
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-telegram/bot/models"
+)
+
+const redactedValue = "[redacted]"
+
+// RedactUserData is the list of update fields with user data, which can be passed to NewUpdateRecorder.
+// It includes user and chat ids and chat titles. Redacted updates keep the shape used to route them to handlers:
+// leading /command tokens of texts, media and location objects as empty placeholders
+var RedactUserData = []string{
+	"id", "user_id", "chat_id", "title",
+	"first_name", "last_name", "username", "phone_number", "email", "bio",
+	"text", "caption", "query", "address", "location", "contact", "photo", "shipping_address", "order_info",
+}
+
+// UpdateRecorder writes updates to JSONL, one update per line. Use Middleware to record updates passed to handlers
+// and bot.ReplayUpdates to process recorded updates again
+type UpdateRecorder struct {
+	mx     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+	redact map[string]struct{}
+	// pseudonymKey is the random key of numbers pseudonyms, unique for the recorder
+	pseudonymKey []byte
+}
+
+// NewUpdateRecorder returns UpdateRecorder which writes updates to w.
+// Values of fields with names from redactFields are replaced at any depth of the update:
+// strings with "[redacted]" after the leading /command token, if any, numbers with pseudonyms,
+// objects with empty objects, arrays element by element, other values are removed.
+// The same number gets the same pseudonym in updates of one recorder, so handlers still see the same chats and users
+func NewUpdateRecorder(w io.Writer, redactFields ...string) (*UpdateRecorder, error) {
+	r := &UpdateRecorder{
+		w:            w,
+		redact:       make(map[string]struct{}, len(redactFields)),
+		pseudonymKey: make([]byte, 32),
+	}
+	for _, f := range redactFields {
+		r.redact[f] = struct{}{}
+	}
+	if _, err := rand.Read(r.pseudonymKey); err != nil {
+		return nil, fmt.Errorf("error generate pseudonym key, %w", err)
+	}
+	return r, nil
+}
+
+// OpenUpdateRecorder returns UpdateRecorder which appends updates to the file
+func OpenUpdateRecorder(path string, redactFields ...string) (*UpdateRecorder, error) {
+	f, errOpen := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if errOpen != nil {
+		return nil, errOpen
+	}
+
+	r, errRecorder := NewUpdateRecorder(f, redactFields...)
+	if errRecorder != nil {
+		_ = f.Close()
+		return nil, errRecorder
+	}
+	r.closer = f
+
+	return r, nil
+}
+
+// Middleware records the update and calls the next handler
+func (r *UpdateRecorder) Middleware(next HandlerFunc) HandlerFunc {
+	return func(ctx context.Context, b *Bot, update *models.Update) {
+		if err := r.Record(update); err != nil {
+			b.error("error record update %d, %w", update.ID, err)
+		}
+		next(ctx, b, update)
+	}
+}
+
+// Record writes the update
+func (r *UpdateRecorder) Record(update *models.Update) error {
+	data, errMarshal := json.Marshal(update)
+	if errMarshal != nil {
+		return errMarshal
+	}
+
+	if len(r.redact) > 0 {
+		var errRedact error
+		data, errRedact = r.redactJSON(data)
+		if errRedact != nil {
+			return errRedact
+		}
+	}
+
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	_, err := r.w.Write(append(data, '\n'))
+	return err
+}
+
+// Close closes the file opened by OpenUpdateRecorder
+func (r *UpdateRecorder) Close() error {
+	if r.closer == nil {
+		return nil
+	}
+	return r.closer.Close()
+}
+
+func (r *UpdateRecorder) redactJSON(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(r.redactValue(v))
+}
+
+func (r *UpdateRecorder) redactValue(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, fieldValue := range val {
+			if _, ok := r.redact[k]; !ok {
+				val[k] = r.redactValue(fieldValue)
+				continue
+			}
+			if redacted, ok := r.redactField(fieldValue); ok {
+				val[k] = redacted
+			} else {
+				delete(val, k)
+			}
+		}
+	case []any:
+		for i := range val {
+			val[i] = r.redactValue(val[i])
+		}
+	}
+	return v
+}
+
+// redactField returns the placeholder of the redacted field value, false if the field is removed
+func (r *UpdateRecorder) redactField(v any) (any, bool) {
+	switch val := v.(type) {
+	case string:
+		// keep the command, so the update is routed to the same handler
+		if strings.HasPrefix(val, "/") {
+			command, _, hasArgs := strings.Cut(val, " ")
+			if hasArgs {
+				return command + " " + redactedValue, true
+			}
+			return command, true
+		}
+		return redactedValue, true
+	case json.Number:
+		return r.pseudonym(val), true
+	case map[string]any:
+		return map[string]any{}, true
+	case []any:
+		result := make([]any, 0, len(val))
+		for _, item := range val {
+			if redacted, ok := r.redactField(item); ok {
+				result = append(result, redacted)
+			}
+		}
+		return result, true
+	}
+	return nil, false
+}
+
+// pseudonym returns the number, which replaces the redacted number. The sign is kept, negative ids are chats.
+// The pseudonym is not 0 and is less than 2^52, like Telegram ids
+func (r *UpdateRecorder) pseudonym(n json.Number) json.Number {
+	mac := hmac.New(sha256.New, r.pseudonymKey)
+	mac.Write([]byte(strings.TrimPrefix(n.String(), "-")))
+	p := int64(binary.BigEndian.Uint64(mac.Sum(nil))>>12) + 1
+
+	if strings.HasPrefix(n.String(), "-") {
+		p = -p
+	}
+	return json.Number(strconv.FormatInt(p, 10))
+}
+
+// ReplayUpdates reads updates in JSONL, written by UpdateRecorder, and processes them one by one in the current goroutine.
+// Use the bot with ReplayClient to run handlers offline. Returns the number of processed updates
+func (b *Bot) ReplayUpdates(ctx context.Context, r io.Reader) (int, error) {
+	var count, line int
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16<<20)
+
+	for scanner.Scan() {
+		line++
+		if ctx.Err() != nil {
+			return count, ctx.Err()
+		}
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		update := &models.Update{}
+		if err := json.Unmarshal(scanner.Bytes(), update); err != nil {
+			return count, fmt.Errorf("error decode update on line %d, %w", line, err)
+		}
+
+		b.processUpdate(ctx, update)
+		count++
+	}
+
+	return count, scanner.Err()
+}
+
+// ReplayCall is a request to Bot API received by ReplayClient
+type ReplayCall struct {
+	Method      string
+	ContentType string
+	Body        []byte
+}
+
+// replayResults are default results of methods by the result: True, numbers, strings, arrays and union types.
+// Methods not listed here return an empty object
+var replayResults = map[string][]string{
+	"true": {
+		"setWebhook", "deleteWebhook", "logOut", "close", "sendChatAction", "setMessageReaction", "setUserEmojiStatus",
+		"banChatMember", "unbanChatMember", "restrictChatMember", "promoteChatMember", "setChatAdministratorCustomTitle",
+		"banChatSenderChat", "unbanChatSenderChat", "setChatPermissions", "approveChatJoinRequest", "declineChatJoinRequest",
+		"setChatPhoto", "deleteChatPhoto", "setChatTitle", "setChatDescription", "pinChatMessage", "unpinChatMessage",
+		"unpinAllChatMessages", "leaveChat", "setChatStickerSet", "deleteChatStickerSet", "editForumTopic", "closeForumTopic",
+		"reopenForumTopic", "deleteForumTopic", "unpinAllForumTopicMessages", "editGeneralForumTopic", "closeGeneralForumTopic",
+		"reopenGeneralForumTopic", "hideGeneralForumTopic", "unhideGeneralForumTopic", "unpinAllGeneralForumTopicMessages",
+		"answerCallbackQuery", "setMyCommands", "deleteMyCommands", "setMyName", "setMyDescription", "setMyShortDescription",
+		"setChatMenuButton", "setMyDefaultAdministratorRights", "deleteMessage", "deleteMessages", "createNewStickerSet",
+		"addStickerToSet", "setStickerPositionInSet", "deleteStickerFromSet", "replaceStickerInSet", "setStickerEmojiList",
+		"setStickerKeywords", "setStickerMaskPosition", "setStickerSetTitle", "setStickerSetThumbnail",
+		"setCustomEmojiStickerSetThumbnail", "deleteStickerSet", "answerInlineQuery", "answerShippingQuery",
+		"answerPreCheckoutQuery", "refundStarPayment", "editUserStarSubscription", "setPassportDataErrors", "sendGift",
+		"giftPremiumSubscription", "verifyUser", "verifyChat", "removeUserVerification", "removeChatVerification",
+	},
+	"0":  {"getChatMemberCount"},
+	`""`: {"exportChatInviteLink", "createInvoiceLink"},
+	"[]": {
+		"getUpdates", "sendMediaGroup", "forwardMessages", "copyMessages", "getChatAdministrators", "getMyCommands",
+		"getForumTopicIconStickers", "getCustomEmojiStickers", "getGameHighScores",
+	},
+	`{"status":"member","user":{}}`: {"getChatMember"},
+	`{"type":"default"}`:            {"getChatMenuButton"},
+}
+
+// replayMethodResults are default results by method, built from replayResults
+var replayMethodResults = func() map[string]string {
+	results := map[string]string{}
+	for result, methods := range replayResults {
+		for _, method := range methods {
+			results[strings.ToLower(method)] = result
+		}
+	}
+	return results
+}()
+
+// ReplayClient is a stub HttpClient, which does not send requests to Telegram and records them instead.
+// Responds with the result from Results by method name, or with a default result of the method result type:
+// true, 0, an empty string, an empty array or an empty object
+type ReplayClient struct {
+	Results map[string]string
+
+	mx    sync.Mutex
+	calls []ReplayCall
+}
+
+// NewReplayClient returns new ReplayClient
+func NewReplayClient() *ReplayClient {
+	return &ReplayClient{
+		Results: map[string]string{},
+	}
+}
+
+func (c *ReplayClient) Do(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var errRead error
+		body, errRead = io.ReadAll(req.Body)
+		if errRead != nil {
+			return nil, errRead
+		}
+	}
+
+	method := path.Base(req.URL.Path)
+
+	c.mx.Lock()
+	c.calls = append(c.calls, ReplayCall{Method: method, ContentType: req.Header.Get("Content-Type"), Body: body})
+	result, ok := c.Results[method]
+	c.mx.Unlock()
+
+	if !ok {
+		result = replayDefaultResult(method)
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(`{"ok":true,"result":` + result + `}`)),
+		Request:    req,
+	}, nil
+}
+
+// Calls returns the recorded requests
+func (c *ReplayClient) Calls() []ReplayCall {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	return append([]ReplayCall(nil), c.calls...)
+}
+
+func replayDefaultResult(method string) string {
+	if result, ok := replayMethodResults[strings.ToLower(method)]; ok {
+		return result
+	}
+	return "{}"
+}
@@ -0,0 +1,214 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestUpdateRecorder_Redact(t *testing.T) {
+	buf := &bytes.Buffer{}
+	r, err := NewUpdateRecorder(buf, "first_name", "contact")
+	assertNoErr(t, err)
+
+	err = r.Record(&models.Update{
+		ID: 1,
+		Message: &models.Message{
+			ID:      2,
+			From:    &models.User{ID: 3, FirstName: "John"},
+			Text:    "/start",
+			Contact: &models.Contact{PhoneNumber: "123"},
+		},
+	})
+	assertNoErr(t, err)
+
+	line := buf.String()
+	assertTrue(t, strings.HasSuffix(line, "\n"))
+	assertTrue(t, strings.Contains(line, `"first_name":"[redacted]"`))
+	assertTrue(t, strings.Contains(line, `"text":"/start"`))
+	assertTrue(t, !strings.Contains(line, "John"))
+	assertTrue(t, strings.Contains(line, `"contact":{}`))
+	assertTrue(t, !strings.Contains(line, "123"))
+}
+
+func TestBot_ReplayUpdates(t *testing.T) {
+	buf := &bytes.Buffer{}
+	recorder, err := NewUpdateRecorder(buf)
+	assertNoErr(t, err)
+
+	var texts []string
+	handler := func(ctx context.Context, b *Bot, update *models.Update) {
+		texts = append(texts, update.Message.Text)
+		_, err := b.SendMessage(ctx, &SendMessageParams{ChatID: update.Message.Chat.ID, Text: "reply"})
+		assertNoErr(t, err)
+		_, err = b.SendChatAction(ctx, &SendChatActionParams{ChatID: update.Message.Chat.ID, Action: models.ChatActionTyping})
+		assertNoErr(t, err)
+	}
+
+	recording := &Bot{
+		defaultHandlerFunc: func(ctx context.Context, b *Bot, update *models.Update) {},
+		middlewares:        []Middleware{recorder.Middleware},
+		errorsHandler:      func(err error) { t.Error(err) },
+	}
+
+	ctx := context.Background()
+
+	recording.processUpdate(ctx, &models.Update{ID: 1, Message: &models.Message{Text: "one", Chat: models.Chat{ID: 10}}})
+	recording.processUpdate(ctx, &models.Update{ID: 2, Message: &models.Message{Text: "two", Chat: models.Chat{ID: 10}}})
+
+	client := NewReplayClient()
+
+	b, err := New("xxx", WithSkipGetMe(), WithHTTPClient(0, client), WithDefaultHandler(handler))
+	assertNoErr(t, err)
+
+	count, err := b.ReplayUpdates(ctx, buf)
+	assertNoErr(t, err)
+	assertEqualInt(t, count, 2)
+	assertEqualInt(t, len(texts), 2)
+	assertEqualString(t, texts[1], "two")
+
+	calls := client.Calls()
+	assertEqualInt(t, len(calls), 4)
+	assertEqualString(t, calls[0].Method, "sendMessage")
+	assertEqualString(t, calls[1].Method, "sendChatAction")
+	assertTrue(t, strings.Contains(string(calls[0].Body), `"text":"reply"`))
+}
+
+func TestReplayDefaultResult(t *testing.T) {
+	assertEqualString(t, replayDefaultResult("sendMessage"), "{}")
+	assertEqualString(t, replayDefaultResult("sendChatAction"), "true")
+	assertEqualString(t, replayDefaultResult("deleteMessage"), "true")
+	assertEqualString(t, replayDefaultResult("editMessageText"), "{}")
+	assertEqualString(t, replayDefaultResult("editForumTopic"), "true")
+	assertEqualString(t, replayDefaultResult("sendMediaGroup"), "[]")
+	assertEqualString(t, replayDefaultResult("setGameScore"), "{}")
+	assertEqualString(t, replayDefaultResult("getChatMemberCount"), "0")
+	assertEqualString(t, replayDefaultResult("exportChatInviteLink"), `""`)
+	assertEqualString(t, replayDefaultResult("sendGift"), "true")
+	assertEqualString(t, replayDefaultResult("giftPremiumSubscription"), "true")
+}
+
+func TestReplayClient_DefaultResults(t *testing.T) {
+	b, err := New("xxx", WithSkipGetMe(), WithHTTPClient(0, NewReplayClient()))
+	assertNoErr(t, err)
+
+	ctx := context.Background()
+
+	count, err := b.GetChatMemberCount(ctx, &GetChatMemberCountParams{ChatID: 1})
+	assertNoErr(t, err)
+	assertEqualInt(t, count, 0)
+
+	_, err = b.ExportChatInviteLink(ctx, &ExportChatInviteLinkParams{ChatID: 1})
+	assertNoErr(t, err)
+
+	_, err = b.CreateInvoiceLink(ctx, &CreateInvoiceLinkParams{Title: "foo"})
+	assertNoErr(t, err)
+
+	_, err = b.SendGift(ctx, &SendGiftParams{UserID: 1, GiftID: "foo"})
+	assertNoErr(t, err)
+
+	_, err = b.GetChatMember(ctx, &GetChatMemberParams{ChatID: 1, UserID: 1})
+	assertNoErr(t, err)
+
+	_, err = b.GetChatMenuButton(ctx, &GetChatMenuButtonParams{})
+	assertNoErr(t, err)
+
+	_, err = b.Logout(ctx)
+	assertNoErr(t, err)
+}
+
+func TestBot_ReplayUpdates_ErrorLine(t *testing.T) {
+	b, err := New("xxx", WithSkipGetMe(), WithHTTPClient(0, NewReplayClient()))
+	assertNoErr(t, err)
+
+	_, err = b.ReplayUpdates(context.Background(), strings.NewReader("{\"update_id\":1}\n\n\nfoo\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 4") {
+		t.Fatalf("expected error on line 4, got %v", err)
+	}
+}
+
+func TestUpdateRecorder_RedactUserData(t *testing.T) {
+	buf := &bytes.Buffer{}
+	r, err := NewUpdateRecorder(buf, RedactUserData...)
+	assertNoErr(t, err)
+
+	err = r.Record(&models.Update{
+		ID: 1,
+		Message: &models.Message{
+			ID:   2,
+			From: &models.User{ID: 123456789},
+			Chat: models.Chat{ID: -100123456789, Title: "Secret group"},
+		},
+	})
+	assertNoErr(t, err)
+	err = r.Record(&models.Update{
+		ID:      2,
+		Message: &models.Message{ID: 3, From: &models.User{ID: 123456789}},
+	})
+	assertNoErr(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assertEqualInt(t, len(lines), 2)
+	assertTrue(t, !strings.Contains(buf.String(), "123456789"))
+	assertTrue(t, !strings.Contains(buf.String(), "Secret group"))
+
+	var upd1, upd2 models.Update
+	assertNoErr(t, json.Unmarshal([]byte(lines[0]), &upd1))
+	assertNoErr(t, json.Unmarshal([]byte(lines[1]), &upd2))
+
+	assertEqualInt(t, int(upd1.ID), 1)
+	assertEqualInt(t, upd1.Message.ID, 2)
+	assertTrue(t, upd1.Message.Chat.ID < 0)
+	assertTrue(t, upd1.Message.From.ID > 0)
+	// the same user gets the same pseudonym
+	assertEqualInt(t, int(upd1.Message.From.ID), int(upd2.Message.From.ID))
+}
+
+func TestUpdateRecorder_RedactUserData_Routing(t *testing.T) {
+	buf := &bytes.Buffer{}
+	r, err := NewUpdateRecorder(buf, RedactUserData...)
+	assertNoErr(t, err)
+
+	assertNoErr(t, r.Record(&models.Update{ID: 1, Message: &models.Message{
+		Text:     "/start secret-payload",
+		Entities: []models.MessageEntity{{Type: models.MessageEntityTypeBotCommand, Offset: 0, Length: 6}},
+	}}))
+	assertNoErr(t, r.Record(&models.Update{ID: 2, Message: &models.Message{
+		Photo:   []models.PhotoSize{{FileID: "a"}, {FileID: "b"}},
+		Caption: "my photo",
+	}}))
+	assertNoErr(t, r.Record(&models.Update{ID: 3, Message: &models.Message{Location: &models.Location{Latitude: 1, Longitude: 2}}}))
+	assertTrue(t, !strings.Contains(buf.String(), "secret-payload"))
+	assertTrue(t, !strings.Contains(buf.String(), "my photo"))
+
+	var routed []string
+	b, err := New("xxx", WithSkipGetMe(), WithHTTPClient(0, NewReplayClient()),
+		WithDefaultHandler(func(ctx context.Context, b *Bot, update *models.Update) {
+			routed = append(routed, "default")
+		}),
+	)
+	assertNoErr(t, err)
+
+	b.RegisterHandler(HandlerTypeMessageText, "/start", MatchTypePrefix, func(ctx context.Context, b *Bot, update *models.Update) {
+		routed = append(routed, "start")
+	})
+	b.RegisterHandlerMatchFunc(func(update *models.Update) bool {
+		return update.Message != nil && len(update.Message.Photo) == 2
+	}, func(ctx context.Context, b *Bot, update *models.Update) {
+		routed = append(routed, "photo")
+	})
+	b.RegisterHandlerMatchFunc(func(update *models.Update) bool {
+		return update.Message != nil && update.Message.Location != nil
+	}, func(ctx context.Context, b *Bot, update *models.Update) {
+		routed = append(routed, "location")
+	})
+
+	count, err := b.ReplayUpdates(context.Background(), buf)
+	assertNoErr(t, err)
+	assertEqualInt(t, count, 3)
+	assertEqualString(t, strings.Join(routed, ","), "start,photo,location")
+}
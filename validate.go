@@ -0,0 +1,401 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/go-telegram/bot/models"
+)
+
+// Bot API limits checked by params validation.
+// maxInlineQueryResults is 50, the limit of answerInlineQuery in the Bot API documentation, not 100
+const (
+	maxMessageTextLength  = 4096
+	maxCaptionLength      = 1024
+	maxCallbackDataSize   = 64
+	minMediaGroupSize     = 2
+	maxMediaGroupSize     = 10
+	maxInlineQueryResults = 50
+)
+
+// ParamsValidator is implemented by params, which check themselves before the request is sent, see WithParamsValidation.
+// Use it for custom params passed to RawRequest
+type ParamsValidator interface {
+	Validate() error
+}
+
+// validationRule checks the params struct, returns the json name of the invalid field and the reason
+type validationRule func(v reflect.Value) (field, reason string)
+
+var (
+	textRule    = ruleAll(ruleRequired("Text"), ruleTextLength("Text", "ParseMode", maxMessageTextLength), ruleExclusive("ParseMode", "Entities"))
+	captionRule = ruleAll(ruleTextLength("Caption", "ParseMode", maxCaptionLength), ruleExclusive("ParseMode", "CaptionEntities"))
+	markupRule  = ruleCallbackData("ReplyMarkup")
+)
+
+// paramsRules are validation rules of params types. Params of other types are checked only by ParamsValidator
+var paramsRules = map[reflect.Type][]validationRule{
+	reflect.TypeOf(SendMessageParams{}):                       withChat(textRule, markupRule),
+	reflect.TypeOf(ForwardMessageParams{}):                    withChat(ruleChatID("FromChatID")),
+	reflect.TypeOf(ForwardMessagesParams{}):                   withChat(ruleChatID("FromChatID")),
+	reflect.TypeOf(CopyMessageParams{}):                       withChat(ruleChatID("FromChatID"), captionRule, markupRule),
+	reflect.TypeOf(CopyMessagesParams{}):                      withChat(ruleChatID("FromChatID")),
+	reflect.TypeOf(SendPhotoParams{}):                         withChat(captionRule, markupRule),
+	reflect.TypeOf(SendAudioParams{}):                         withChat(captionRule, markupRule),
+	reflect.TypeOf(SendDocumentParams{}):                      withChat(captionRule, markupRule),
+	reflect.TypeOf(SendVideoParams{}):                         withChat(captionRule, markupRule),
+	reflect.TypeOf(SendAnimationParams{}):                     withChat(captionRule, markupRule),
+	reflect.TypeOf(SendVoiceParams{}):                         withChat(captionRule, markupRule),
+	reflect.TypeOf(SendVideoNoteParams{}):                     withChat(markupRule),
+	reflect.TypeOf(SendPaidMediaParams{}):                     withChat(captionRule, markupRule),
+	reflect.TypeOf(SendMediaGroupParams{}):                    withChat(ruleCount("Media", minMediaGroupSize, maxMediaGroupSize), ruleMediaCaptions("Media")),
+	reflect.TypeOf(SendLocationParams{}):                      withChat(markupRule),
+	reflect.TypeOf(EditMessageLiveLocationParams{}):           withMessageRef(markupRule),
+	reflect.TypeOf(StopMessageLiveLocationParams{}):           withMessageRef(markupRule),
+	reflect.TypeOf(SendVenueParams{}):                         withChat(markupRule),
+	reflect.TypeOf(SendContactParams{}):                       withChat(markupRule),
+	reflect.TypeOf(SendPollParams{}):                          withChat(markupRule),
+	reflect.TypeOf(SendDiceParams{}):                          withChat(markupRule),
+	reflect.TypeOf(SendChatActionParams{}):                    withChat(),
+	reflect.TypeOf(SetMessageReactionParams{}):                withChat(),
+	reflect.TypeOf(BanChatMemberParams{}):                     withChat(),
+	reflect.TypeOf(UnbanChatMemberParams{}):                   withChat(),
+	reflect.TypeOf(RestrictChatMemberParams{}):                withChat(),
+	reflect.TypeOf(PromoteChatMemberParams{}):                 withChat(),
+	reflect.TypeOf(SetChatAdministratorCustomTitleParams{}):   withChat(),
+	reflect.TypeOf(BanChatSenderChatParams{}):                 withChat(),
+	reflect.TypeOf(UnbanChatSenderChatParams{}):               withChat(),
+	reflect.TypeOf(SetChatPermissionsParams{}):                withChat(),
+	reflect.TypeOf(ExportChatInviteLinkParams{}):              withChat(),
+	reflect.TypeOf(CreateChatInviteLinkParams{}):              withChat(),
+	reflect.TypeOf(EditChatInviteLinkParams{}):                withChat(),
+	reflect.TypeOf(CreateChatSubscriptionInviteLinkParams{}):  withChat(),
+	reflect.TypeOf(EditChatSubscriptionInviteLinkParams{}):    withChat(),
+	reflect.TypeOf(RevokeChatInviteLinkParams{}):              withChat(),
+	reflect.TypeOf(ApproveChatJoinRequestParams{}):            withChat(),
+	reflect.TypeOf(DeclineChatJoinRequestParams{}):            withChat(),
+	reflect.TypeOf(SetChatPhotoParams{}):                      withChat(),
+	reflect.TypeOf(DeleteChatPhotoParams{}):                   withChat(),
+	reflect.TypeOf(SetChatTitleParams{}):                      withChat(),
+	reflect.TypeOf(SetChatDescriptionParams{}):                withChat(),
+	reflect.TypeOf(PinChatMessageParams{}):                    withChat(),
+	reflect.TypeOf(UnpinChatMessageParams{}):                  withChat(),
+	reflect.TypeOf(UnpinAllChatMessagesParams{}):              withChat(),
+	reflect.TypeOf(LeaveChatParams{}):                         withChat(),
+	reflect.TypeOf(GetChatParams{}):                           withChat(),
+	reflect.TypeOf(GetChatAdministratorsParams{}):             withChat(),
+	reflect.TypeOf(GetChatMemberCountParams{}):                withChat(),
+	reflect.TypeOf(GetChatMemberParams{}):                     withChat(),
+	reflect.TypeOf(SetChatStickerSetParams{}):                 withChat(),
+	reflect.TypeOf(CreateForumTopicParams{}):                  withChat(),
+	reflect.TypeOf(EditForumTopicParams{}):                    withChat(),
+	reflect.TypeOf(CloseForumTopicParams{}):                   withChat(),
+	reflect.TypeOf(ReopenForumTopicParams{}):                  withChat(),
+	reflect.TypeOf(DeleteForumTopicParams{}):                  withChat(),
+	reflect.TypeOf(UnpinAllForumTopicMessagesParams{}):        withChat(),
+	reflect.TypeOf(EditGeneralForumTopicParams{}):             withChat(),
+	reflect.TypeOf(CloseGeneralForumTopicParams{}):            withChat(),
+	reflect.TypeOf(ReopenGeneralForumTopicParams{}):           withChat(),
+	reflect.TypeOf(HideGeneralForumTopicParams{}):             withChat(),
+	reflect.TypeOf(UnhideGeneralForumTopicParams{}):           withChat(),
+	reflect.TypeOf(UnpinAllGeneralForumTopicMessagesParams{}): withChat(),
+	reflect.TypeOf(DeleteChatStickerSetParams{}):              withChat(),
+	reflect.TypeOf(GetUserChatBoostsParams{}):                 withChat(),
+	reflect.TypeOf(SetChatMenuButtonParams{}):                 {ruleChatID("ChatID")},
+	reflect.TypeOf(GetChatMenuButtonParams{}):                 {ruleChatID("ChatID")},
+	reflect.TypeOf(EditMessageTextParams{}):                   withMessageRef(textRule, markupRule),
+	reflect.TypeOf(EditMessageCaptionParams{}):                withMessageRef(captionRule, markupRule),
+	reflect.TypeOf(EditMessageMediaParams{}):                  withMessageRef(markupRule, ruleMediaCaptions("Media")),
+	reflect.TypeOf(EditMessageReplyMarkupParams{}):            withMessageRef(markupRule),
+	reflect.TypeOf(StopPollParams{}):                          withChat(markupRule),
+	reflect.TypeOf(DeleteMessageParams{}):                     withChat(),
+	reflect.TypeOf(DeleteMessagesParams{}):                    withChat(),
+	reflect.TypeOf(SendStickerParams{}):                       withChat(markupRule),
+	reflect.TypeOf(AnswerInlineQueryParams{}):                 {ruleCount("Results", 0, maxInlineQueryResults)},
+	reflect.TypeOf(SendInvoiceParams{}):                       withChat(markupRule),
+	reflect.TypeOf(SendGameParams{}):                          withChat(markupRule),
+	reflect.TypeOf(SetGameScoreParams{}):                      withMessageRef(),
+	reflect.TypeOf(GetGameHighScoresParams{}):                 withMessageRef(),
+	reflect.TypeOf(VerifyChatParams{}):                        withChat(),
+	reflect.TypeOf(RemoveChatVerificationParams{}):            withChat(),
+}
+
+// withChat returns rules of params with the required chat id and the rules
+func withChat(rules ...validationRule) []validationRule {
+	return append([]validationRule{ruleRequired("ChatID"), ruleChatID("ChatID")}, rules...)
+}
+
+// withMessageRef returns rules of params of edit methods, which refer to a message in a chat or an inline message, and the rules
+func withMessageRef(rules ...validationRule) []validationRule {
+	return append([]validationRule{ruleMessageRef("ChatID", "MessageID", "InlineMessageID"), ruleChatID("ChatID")}, rules...)
+}
+
+// validateParams returns *ValidationError if params break the rules of their type
+func validateParams(method string, params any) error {
+	if params == nil {
+		return nil
+	}
+
+	if validator, ok := params.(ParamsValidator); ok {
+		if err := validator.Validate(); err != nil {
+			var validationErr *ValidationError
+			if errors.As(err, &validationErr) {
+				validationErr.Method = method
+				return validationErr
+			}
+			return &ValidationError{Method: method, Reason: err.Error()}
+		}
+	}
+
+	v := reflect.ValueOf(params)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	v = v.Elem()
+
+	for _, rule := range paramsRules[v.Type()] {
+		if field, reason := rule(v); reason != "" {
+			return &ValidationError{Method: method, Field: field, Reason: reason}
+		}
+	}
+
+	return nil
+}
+
+// jsonName returns the json name of the struct field
+func jsonName(v reflect.Value, field string) string {
+	f, _ := v.Type().FieldByName(field)
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	return name
+}
+
+// ruleAll returns the first failure of the rules
+func ruleAll(rules ...validationRule) validationRule {
+	return func(v reflect.Value) (string, string) {
+		for _, rule := range rules {
+			if field, reason := rule(v); reason != "" {
+				return field, reason
+			}
+		}
+		return "", ""
+	}
+}
+
+func ruleRequired(field string) validationRule {
+	return func(v reflect.Value) (string, string) {
+		if v.FieldByName(field).IsZero() {
+			return jsonName(v, field), "is required"
+		}
+		return "", ""
+	}
+}
+
+// ruleTextLength checks the length of the text in UTF-16 code units, like Telegram does.
+// The length of the text with parse_mode is counted without the markup, see textLength
+func ruleTextLength(field, parseModeField string, max int) validationRule {
+	return func(v reflect.Value) (string, string) {
+		text := v.FieldByName(field).String()
+		parseMode := models.ParseMode(v.FieldByName(parseModeField).String())
+		if n := textLength(text, parseMode); n > max {
+			return jsonName(v, field), fmt.Sprintf("is %d characters long, max %d", n, max)
+		}
+		return "", ""
+	}
+}
+
+func ruleExclusive(field, otherField string) validationRule {
+	return func(v reflect.Value) (string, string) {
+		if !v.FieldByName(field).IsZero() && !v.FieldByName(otherField).IsZero() {
+			return jsonName(v, field), "must not be set together with " + jsonName(v, otherField)
+		}
+		return "", ""
+	}
+}
+
+func ruleCount(field string, min, max int) validationRule {
+	return func(v reflect.Value) (string, string) {
+		if n := v.FieldByName(field).Len(); n < min || n > max {
+			return jsonName(v, field), fmt.Sprintf("has %d items, must have from %d to %d", n, min, max)
+		}
+		return "", ""
+	}
+}
+
+// ruleMediaCaptions checks captions of models.InputMedia items: their length and parse_mode together with caption_entities
+func ruleMediaCaptions(field string) validationRule {
+	return func(v reflect.Value) (string, string) {
+		f := v.FieldByName(field)
+		if f.Kind() != reflect.Slice {
+			return checkMediaCaption(f, jsonName(v, field)+" caption")
+		}
+		for i := 0; i < f.Len(); i++ {
+			if name, reason := checkMediaCaption(f.Index(i), fmt.Sprintf("%s[%d] caption", jsonName(v, field), i)); reason != "" {
+				return name, reason
+			}
+		}
+		return "", ""
+	}
+}
+
+// checkMediaCaption checks the caption of the media, which is an interface with a pointer to InputMedia* struct
+func checkMediaCaption(media reflect.Value, name string) (string, string) {
+	if media.Kind() == reflect.Interface {
+		media = media.Elem()
+	}
+	if media.Kind() != reflect.Ptr || media.IsNil() || media.Elem().Kind() != reflect.Struct {
+		return "", ""
+	}
+	media = media.Elem()
+
+	caption, parseMode, entities := media.FieldByName("Caption"), media.FieldByName("ParseMode"), media.FieldByName("CaptionEntities")
+	if !caption.IsValid() || !parseMode.IsValid() || !entities.IsValid() {
+		return "", ""
+	}
+
+	if n := textLength(caption.String(), models.ParseMode(parseMode.String())); n > maxCaptionLength {
+		return name, fmt.Sprintf("is %d characters long, max %d", n, maxCaptionLength)
+	}
+	if !parseMode.IsZero() && !entities.IsZero() {
+		return name, "parse_mode must not be set together with caption_entities"
+	}
+	return "", ""
+}
+
+// ruleMessageRef checks that edit methods have either chat_id and message_id or inline_message_id
+func ruleMessageRef(chatIDField, messageIDField, inlineMessageIDField string) validationRule {
+	return func(v reflect.Value) (string, string) {
+		if !v.FieldByName(inlineMessageIDField).IsZero() {
+			return "", ""
+		}
+		if isZeroChatID(v.FieldByName(chatIDField)) {
+			return jsonName(v, chatIDField), "is required if inline_message_id is not set"
+		}
+		if v.FieldByName(messageIDField).IsZero() {
+			return jsonName(v, messageIDField), "is required if inline_message_id is not set"
+		}
+		return "", ""
+	}
+}
+
+func isZeroChatID(f reflect.Value) bool {
+	if f.IsZero() {
+		return true
+	}
+	switch value := f.Interface().(type) {
+	case models.ChatID:
+		return value.IsZero()
+	case *models.ChatID:
+		return value == nil || value.IsZero()
+	}
+	return false
+}
+
+// ruleChatID checks that the chat id is an integer, models.ChatID or a string with an integer or @channelusername
+func ruleChatID(field string) validationRule {
+	return func(v reflect.Value) (string, string) {
+		f := v.FieldByName(field)
+		if f.IsNil() {
+			return "", ""
+		}
+		name := jsonName(v, field)
+
+		switch value := f.Interface().(type) {
+		case models.ChatID, *models.ChatID:
+			if isZeroChatID(f) {
+				return name, "is required"
+			}
+			return "", ""
+		case string:
+			if _, err := models.ParseChatID(value); err != nil {
+				return name, fmt.Sprintf("must be an integer or @channelusername, got %q", value)
+			}
+			return "", ""
+		}
+
+		switch f.Elem().Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return "", ""
+		}
+
+		return name, fmt.Sprintf("must be an integer, @channelusername or models.ChatID, got %s", f.Elem().Type())
+	}
+}
+
+func ruleCallbackData(field string) validationRule {
+	return func(v reflect.Value) (string, string) {
+		var markup *models.InlineKeyboardMarkup
+		switch m := v.FieldByName(field).Interface().(type) {
+		case *models.InlineKeyboardMarkup:
+			markup = m
+		case models.InlineKeyboardMarkup:
+			markup = &m
+		}
+		if markup == nil {
+			return "", ""
+		}
+
+		for _, row := range markup.InlineKeyboard {
+			for _, button := range row {
+				if len(button.CallbackData) > maxCallbackDataSize {
+					return jsonName(v, field), fmt.Sprintf("callback data of button %q is %d bytes long, max %d", button.Text, len(button.CallbackData), maxCallbackDataSize)
+				}
+			}
+		}
+		return "", ""
+	}
+}
+
+var (
+	reHTMLTag        = regexp.MustCompile(`<[^>]*>`)
+	reMarkdownLink   = regexp.MustCompile(`\]\((?:[^)\\]|\\.)*\)`)
+	reMarkdownPreTag = regexp.MustCompile("```[^\n`]*\n")
+)
+
+// textLength returns the length of the text in UTF-16 code units without the markup of the parse mode:
+// HTML tags are removed and entities are unescaped, Markdown link URLs, language names of code blocks,
+// formatting characters and escaping backslashes are removed.
+// The length of Markdown text can be less than the length counted by Telegram, if formatting characters are used
+// as a text inside code blocks, then too long text is sent and rejected by Telegram
+func textLength(text string, parseMode models.ParseMode) int {
+	switch parseMode {
+	case models.ParseModeHTML:
+		text = html.UnescapeString(reHTMLTag.ReplaceAllString(text, ""))
+	case models.ParseModeMarkdown, models.ParseModeMarkdownV1:
+		text = stripMarkdown(text, parseMode)
+	}
+
+	return len(utf16.Encode([]rune(text)))
+}
+
+func stripMarkdown(text string, parseMode models.ParseMode) string {
+	text = reMarkdownPreTag.ReplaceAllString(text, "")
+	text = reMarkdownLink.ReplaceAllString(text, "")
+
+	formatting := "*_`["
+	if parseMode == models.ParseModeMarkdown {
+		formatting = "*_`[~|>!"
+	}
+
+	var result strings.Builder
+	escaped := false
+	for _, r := range text {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+			continue
+		case strings.ContainsRune(formatting, r):
+			continue
+		}
+		result.WriteRune(r)
+	}
+
+	return result.String()
+}
@@ -0,0 +1,130 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-telegram/bot/models"
+)
+
+type customParams struct {
+	Value string `json:"value"`
+}
+
+func (p *customParams) Validate() error {
+	if p.Value == "" {
+		return errors.New("value is empty")
+	}
+	return nil
+}
+
+func TestValidateParams(t *testing.T) {
+	longCallbackData := &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{
+		{{Text: "button", CallbackData: strings.Repeat("x", 65)}},
+	}}
+
+	tests := []struct {
+		name   string
+		method string
+		params any
+		field  string
+	}{
+		{name: "valid", method: "sendMessage", params: &SendMessageParams{ChatID: 1, Text: "text"}},
+		{name: "no chat id", method: "sendMessage", params: &SendMessageParams{Text: "text"}, field: "chat_id"},
+		{name: "no text", method: "sendMessage", params: &SendMessageParams{ChatID: 1}, field: "text"},
+		{name: "long text", method: "sendMessage", params: &SendMessageParams{ChatID: 1, Text: strings.Repeat("x", 4097)}, field: "text"},
+		{name: "long text utf16", method: "sendMessage", params: &SendMessageParams{ChatID: 1, Text: strings.Repeat("😀", 2049)}, field: "text"},
+		{name: "long text with parse mode", method: "sendMessage", params: &SendMessageParams{ChatID: 1, Text: strings.Repeat("x", 4097), ParseMode: models.ParseModeHTML}, field: "text"},
+		{name: "long markup with parse mode", method: "sendMessage", params: &SendMessageParams{ChatID: 1, Text: "<b>" + strings.Repeat("&lt;", 4096) + "</b>", ParseMode: models.ParseModeHTML}},
+		{name: "parse mode with entities", method: "sendMessage", params: &SendMessageParams{ChatID: 1, Text: "text", ParseMode: models.ParseModeHTML, Entities: []models.MessageEntity{{}}}, field: "parse_mode"},
+		{name: "long callback data", method: "sendMessage", params: &SendMessageParams{ChatID: 1, Text: "text", ReplyMarkup: longCallbackData}, field: "reply_markup"},
+		{name: "long caption", method: "sendPhoto", params: &SendPhotoParams{ChatID: 1, Caption: strings.Repeat("x", 1025)}, field: "caption"},
+		{name: "parse mode with caption entities", method: "sendPhoto", params: &SendPhotoParams{ChatID: 1, ParseMode: models.ParseModeHTML, CaptionEntities: []models.MessageEntity{{}}}, field: "parse_mode"},
+		{name: "long media group caption", method: "sendMediaGroup", params: &SendMediaGroupParams{ChatID: 1, Media: []models.InputMedia{&models.InputMediaPhoto{}, &models.InputMediaVideo{Caption: strings.Repeat("x", 1025)}}}, field: "media[1] caption"},
+		{name: "media group caption with parse mode and entities", method: "sendMediaGroup", params: &SendMediaGroupParams{ChatID: 1, Media: []models.InputMedia{&models.InputMediaPhoto{Caption: "x", ParseMode: models.ParseModeHTML, CaptionEntities: []models.MessageEntity{{}}}, &models.InputMediaPhoto{}}}, field: "media[0] caption"},
+		{name: "long edited media caption", method: "editMessageMedia", params: &EditMessageMediaParams{InlineMessageID: "1", Media: &models.InputMediaDocument{Caption: strings.Repeat("*x*", 1025), ParseMode: models.ParseModeMarkdown}}, field: "media caption"},
+		{name: "small media group", method: "sendMediaGroup", params: &SendMediaGroupParams{ChatID: 1, Media: []models.InputMedia{&models.InputMediaPhoto{}}}, field: "media"},
+		{name: "many inline results", method: "answerInlineQuery", params: &AnswerInlineQueryParams{InlineQueryID: "1", Results: make([]models.InlineQueryResult, 51)}, field: "results"},
+		{name: "edit inline message", method: "editMessageText", params: &EditMessageTextParams{InlineMessageID: "1", Text: "text"}},
+		{name: "edit without message", method: "editMessageText", params: &EditMessageTextParams{ChatID: 1, Text: "text"}, field: "message_id"},
+		{name: "typed chat id", method: "sendMessage", params: &SendMessageParams{ChatID: models.NewChatUsername("channel"), Text: "text"}},
+		{name: "int32 chat id", method: "sendMessage", params: &SendMessageParams{ChatID: int32(1), Text: "text"}},
+		{name: "numeric string chat id", method: "sendMessage", params: &SendMessageParams{ChatID: "-100123", Text: "text"}},
+		{name: "username without @", method: "sendMessage", params: &SendMessageParams{ChatID: "channel", Text: "text"}, field: "chat_id"},
+		{name: "float chat id", method: "sendMessage", params: &SendMessageParams{ChatID: 1.5, Text: "text"}, field: "chat_id"},
+		{name: "zero typed chat id", method: "sendMessage", params: &SendMessageParams{ChatID: models.ChatID{}, Text: "text"}, field: "chat_id"},
+		{name: "bad from chat id", method: "forwardMessage", params: &ForwardMessageParams{ChatID: 1, FromChatID: "channel", MessageID: 1}, field: "from_chat_id"},
+		{name: "edit with zero typed chat id", method: "editMessageText", params: &EditMessageTextParams{ChatID: &models.ChatID{}, MessageID: 1, Text: "text"}, field: "chat_id"},
+		{name: "default menu button", method: "getChatMenuButton", params: &GetChatMenuButtonParams{}},
+		{name: "bad menu button chat id", method: "getChatMenuButton", params: &GetChatMenuButtonParams{ChatID: "channel"}, field: "chat_id"},
+		{name: "custom", method: "custom", params: &customParams{}},
+		{name: "nil", method: "getMe", params: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateParams(tt.method, tt.params)
+
+			if tt.name == "custom" {
+				assertTrue(t, errors.Is(err, ErrorValidation))
+				assertEqualString(t, err.Error(), "validation error: invalid params for method custom, value is empty")
+				return
+			}
+
+			if tt.field == "" {
+				assertNoErr(t, err)
+				return
+			}
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("expected validation error, got %v", err)
+			}
+			assertEqualString(t, validationErr.Method, tt.method)
+			assertEqualString(t, validationErr.Field, tt.field)
+			assertTrue(t, errors.Is(err, ErrorValidation))
+		})
+	}
+}
+
+func TestParamsRules(t *testing.T) {
+	// rules refer to fields by name, they panic if the type has no such field
+	for typ, rules := range paramsRules {
+		v := reflect.New(typ).Elem()
+		for _, rule := range rules {
+			rule(v)
+		}
+	}
+}
+
+func TestTextLength(t *testing.T) {
+	tests := []struct {
+		text      string
+		parseMode models.ParseMode
+		length    int
+	}{
+		{text: "text 😀", length: 7},
+		{text: "<b>bold</b> <a href=\"https://example.com\">link</a> &amp;", parseMode: models.ParseModeHTML, length: 11},
+		{text: "*bold* _italic_ ||spoiler|| [link](https://example.com/\\)) \\. ![😀](tg://emoji?id=1)", parseMode: models.ParseModeMarkdown, length: 29},
+		{text: "```go\ncode\n```", parseMode: models.ParseModeMarkdown, length: 5},
+		{text: "*bold* [link](https://example.com) \\_", parseMode: models.ParseModeMarkdownV1, length: 11},
+	}
+
+	for _, tt := range tests {
+		assertEqualInt(t, textLength(tt.text, tt.parseMode), tt.length)
+	}
+}
+
+func TestWithParamsValidation(t *testing.T) {
+	client := &httpClient{t: t, resp: `{}`}
+
+	b, err := New("xxx", WithSkipGetMe(), WithHTTPClient(0, client), WithParamsValidation())
+	assertNoErr(t, err)
+
+	_, err = b.SendMessage(context.Background(), &SendMessageParams{Text: "text"})
+	assertTrue(t, errors.Is(err, ErrorValidation))
+	assertEqualString(t, err.Error(), "validation error: invalid params for method sendMessage, chat_id is required")
+}
@@ -1,23 +1,82 @@
 package bot
 
 import (
+	"context"
+	"crypto/subtle"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"reflect"
+	"strings"
+	"sync"
 
 	"github.com/go-telegram/bot/models"
 )
 
+const defaultWebhookMaxBodySize = 2 << 20
+
+var errWebhookBodyTooLarge = errors.New("webhook request body too large")
+
+// telegramSubnets are the networks Telegram sends webhook requests from, see https://core.telegram.org/bots/webhooks
+var telegramSubnets = mustParseCIDRs("149.154.160.0/20", "91.108.4.0/22")
+
+// WebhookHandler returns http handler for webhook updates.
+// The handler responds with
+// 401 for invalid secret token, 403 for requests not from Telegram subnets (see WithWebhookIPCheck),
+// 400 for invalid body, 413 for too large body and 503 if the updates channel is full
 func (b *Bot) WebhookHandler() http.HandlerFunc {
-	return func(_ http.ResponseWriter, req *http.Request) {
-		if b.webhookSecretToken != "" && req.Header.Get("X-Telegram-Bot-Api-Secret-Token") != b.webhookSecretToken {
+	return b.webhookHandler(b.enqueueUpdate)
+}
+
+// enqueueUpdate sends the update to the updates channel, returns false if the channel is full.
+// With the disk queue, returns after the update is written to the disk
+func (b *Bot) enqueueUpdate(update *models.Update) bool {
+	if b.diskQueue != nil {
+		if err := b.diskQueue.add(update); err != nil {
+			b.error("error write update to disk queue, %w", err)
+			return false
+		}
+		return true
+	}
+
+	select {
+	case b.updates <- update:
+		return true
+	default:
+		return false
+	}
+}
+
+// webhookHandler returns webhook handler, which passes updates to enqueue
+func (b *Bot) webhookHandler(enqueue func(update *models.Update) bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if b.webhookSecretToken != "" && !validWebhookSecretToken(req.Header.Get("X-Telegram-Bot-Api-Secret-Token"), b.webhookSecretToken) {
 			b.error("invalid webhook secret token received from update")
+			b.metrics.webhookRejection("unauthorized")
+			w.WriteHeader(http.StatusUnauthorized)
 			return
 		}
 
-		body, errReadBody := io.ReadAll(req.Body)
+		if b.webhookIPCheck && !isTelegramRequest(req, b.webhookTrustForwardedFor) {
+			b.error("webhook request from not allowed address %s", req.RemoteAddr)
+			b.metrics.webhookRejection("forbidden")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		body, errReadBody := readWebhookBody(req.Body, b.webhookMaxBodySize)
 		if errReadBody != nil {
 			b.error("error read request body, %w", errReadBody)
+			if errors.Is(errReadBody, errWebhookBodyTooLarge) {
+				b.metrics.webhookRejection("too_large")
+				w.WriteHeader(http.StatusRequestEntityTooLarge)
+				return
+			}
+			b.metrics.webhookRejection("bad_request")
+			w.WriteHeader(http.StatusBadRequest)
 			return
 		}
 
@@ -26,6 +85,8 @@ func (b *Bot) WebhookHandler() http.HandlerFunc {
 		errDecode := json.Unmarshal(body, update)
 		if errDecode != nil {
 			b.error("error decode request body, %s, %w", body, errDecode)
+			b.metrics.webhookRejection("bad_request")
+			w.WriteHeader(http.StatusBadRequest)
 			return
 		}
 
@@ -36,14 +97,173 @@ func (b *Bot) WebhookHandler() http.HandlerFunc {
 		select {
 		case <-req.Context().Done():
 			b.error("some updates lost, ctx done")
+			b.metrics.webhookRejection("unavailable")
+			w.WriteHeader(http.StatusServiceUnavailable)
 			return
 		default:
 		}
 
-		select {
-		case b.updates <- update:
-		case <-req.Context().Done():
-			b.error("failed to send update, ctx done")
+		if b.syncWebhook {
+			b.serveSyncWebhook(w, req, update)
+			return
+		}
+
+		if !enqueue(update) {
+			b.error("failed to send update, updates queue is full")
+			b.metrics.webhookRejection("queue_full")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// serveSyncWebhook processes the update in the request goroutine and writes the webhook reply, if any.
+// With the disk queue, the update is written to the disk before processing and removed after,
+// so it is handled again after restart if the process crashes
+func (b *Bot) serveSyncWebhook(w http.ResponseWriter, req *http.Request, update *models.Update) {
+	if b.diskQueue != nil {
+		added, err := b.diskQueue.claim(update)
+		if err != nil {
+			b.error("error write update to disk queue, %w", err)
+			b.metrics.webhookRejection("queue_full")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if !added {
+			// the update is already in the queue and will be handled from there
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+	}
+
+	reply := &webhookReply{}
+	ctx := context.WithValue(req.Context(), webhookReplyKey{}, reply)
+
+	b.processUpdate(ctx, update)
+
+	reply.mx.Lock()
+	defer reply.mx.Unlock()
+
+	if reply.body == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(reply.body); err != nil {
+		b.error("error write webhook reply, %w", err)
+	}
+}
+
+type webhookReplyKey struct{}
+
+type webhookReply struct {
+	mx   sync.Mutex
+	body []byte
+}
+
+// WebhookReply sets a Bot API method call as the webhook response body.
+// Available only in handlers called from WebhookHandler with WithSyncWebhook option,
+// params must be a pointer to a params struct without files to upload, or nil.
+// The result of the method call is not available. Only one reply can be set for an update
+// https://core.telegram.org/bots/api#making-requests-when-getting-updates
+func WebhookReply(ctx context.Context, method string, params any) error {
+	reply, ok := ctx.Value(webhookReplyKey{}).(*webhookReply)
+	if !ok {
+		return fmt.Errorf("webhook reply is available only in sync webhook handlers")
+	}
+
+	methodName, _ := json.Marshal(method)
+	body := []byte(`{"method":` + string(methodName) + `}`)
+
+	if !isNilParams(params) {
+		if v := reflect.ValueOf(params); v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+			return fmt.Errorf("error build webhook reply for method %s, params must be a pointer to a struct, got %T", method, params)
+		}
+		if hasUploads(params) {
+			return fmt.Errorf("error build webhook reply for method %s, params contain files to upload", method)
+		}
+		data, fieldsCount, errJSON := buildRequestJSON(withTypedChatIDs(params))
+		if errJSON != nil {
+			return fmt.Errorf("error build webhook reply for method %s, %w", method, errJSON)
+		}
+		if fieldsCount > 0 {
+			body = append(body[:len(body)-1], ',')
+			body = append(body, data[1:]...)
+		}
+	}
+
+	reply.mx.Lock()
+	defer reply.mx.Unlock()
+
+	if reply.body != nil {
+		return fmt.Errorf("webhook reply is already set")
+	}
+	reply.body = body
+
+	return nil
+}
+
+// readWebhookBody reads the body and returns errWebhookBodyTooLarge if it is larger than maxSize. maxSize 0 means no limit
+func readWebhookBody(r io.Reader, maxSize int64) ([]byte, error) {
+	if maxSize <= 0 {
+		return io.ReadAll(r)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > maxSize {
+		return nil, fmt.Errorf("%w, max body size %d", errWebhookBodyTooLarge, maxSize)
+	}
+
+	return body, nil
+}
+
+// isTelegramRequest checks the request source address is in Telegram subnets.
+// If trustForwardedFor is true, the last address of X-Forwarded-For header is checked, set by your proxy
+func isTelegramRequest(req *http.Request, trustForwardedFor bool) bool {
+	addr := req.RemoteAddr
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+
+	if forwarded := req.Header.Get("X-Forwarded-For"); trustForwardedFor && forwarded != "" {
+		parts := strings.Split(forwarded, ",")
+		addr = strings.TrimSpace(parts[len(parts)-1])
+	}
+
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+
+	for _, subnet := range telegramSubnets {
+		if subnet.Contains(ip) {
+			return true
 		}
 	}
+
+	return false
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	nets := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		nets = append(nets, n)
+	}
+	return nets
+}
+
+// validWebhookSecretToken compares the received secret token with the expected one in constant time
+func validWebhookSecretToken(received, expected string) bool {
+	return subtle.ConstantTimeCompare([]byte(received), []byte(expected)) == 1
 }
@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/go-telegram/bot/models"
@@ -100,6 +101,8 @@ func TestWebhookHandler_ReadBodyError(t *testing.T) {
 	handler := bot.WebhookHandler()
 	handler(w, req)
 
+	assertEqualInt(t, w.Code, http.StatusBadRequest)
+
 	if len(errorsHandler.errors) == 0 {
 		t.Fatal("Expected an error, but none occurred")
 	}
@@ -129,6 +132,8 @@ func TestWebhookHandler_DecodeError(t *testing.T) {
 	handler := bot.WebhookHandler()
 	handler(w, req)
 
+	assertEqualInt(t, w.Code, http.StatusBadRequest)
+
 	if len(errorsHandler.errors) == 0 {
 		t.Fatal("Expected an error, but none occurred")
 	}
@@ -185,3 +190,115 @@ func TestWebhookHandler_ContextDone(t *testing.T) {
 func containsString(s, substr string) bool {
 	return bytes.Contains([]byte(s), []byte(substr))
 }
+
+func TestWebhookHandler_StatusCodes(t *testing.T) {
+	newBot := func() *Bot {
+		return &Bot{
+			updates:            make(chan *models.Update, 1),
+			webhookSecretToken: "secret",
+			webhookMaxBodySize: 64,
+			errorsHandler:      func(err error) {},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		secret string
+		body   string
+		full   bool
+		expect int
+	}{
+		{"ok", "secret", `{"update_id":1}`, false, http.StatusOK},
+		{"invalid secret", "foo", `{"update_id":1}`, false, http.StatusUnauthorized},
+		{"invalid body", "secret", `{invalid json}`, false, http.StatusBadRequest},
+		{"too large body", "secret", `{"update_id":1,"message":{"text":"` + strings.Repeat("x", 64) + `"}}`, false, http.StatusRequestEntityTooLarge},
+		{"updates channel is full", "secret", `{"update_id":1}`, true, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newBot()
+			if tt.full {
+				b.updates <- &models.Update{}
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			req.Header.Set("X-Telegram-Bot-Api-Secret-Token", tt.secret)
+			w := httptest.NewRecorder()
+
+			b.WebhookHandler()(w, req)
+
+			assertEqualInt(t, w.Code, tt.expect)
+		})
+	}
+}
+
+func TestWebhookHandler_IPCheck(t *testing.T) {
+	tests := []struct {
+		name              string
+		remoteAddr        string
+		forwardedFor      string
+		trustForwardedFor bool
+		expect            int
+	}{
+		{"telegram address", "149.154.167.220:443", "", false, http.StatusOK},
+		{"telegram address 2", "91.108.6.1:443", "", false, http.StatusOK},
+		{"other address", "10.0.0.1:443", "", false, http.StatusForbidden},
+		{"untrusted forwarded for", "10.0.0.1:443", "149.154.167.220", false, http.StatusForbidden},
+		{"trusted forwarded for", "10.0.0.1:443", "1.2.3.4, 149.154.167.220", true, http.StatusOK},
+		{"spoofed forwarded for", "10.0.0.1:443", "149.154.167.220, 1.2.3.4", true, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &Bot{
+				updates:                  make(chan *models.Update, 1),
+				webhookIPCheck:           true,
+				webhookTrustForwardedFor: tt.trustForwardedFor,
+				errorsHandler:            func(err error) {},
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"update_id":1}`))
+			req.RemoteAddr = tt.remoteAddr
+			if tt.forwardedFor != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+			w := httptest.NewRecorder()
+
+			b.WebhookHandler()(w, req)
+
+			assertEqualInt(t, w.Code, tt.expect)
+		})
+	}
+}
+
+func TestWebhookHandler_SyncReply(t *testing.T) {
+	b := &Bot{
+		syncWebhook: true,
+		defaultHandlerFunc: func(ctx context.Context, _ *Bot, update *models.Update) {
+			err := WebhookReply(ctx, "sendMessage", &SendMessageParams{ChatID: update.Message.Chat.ID, Text: "pong"})
+			assertNoErr(t, err)
+
+			err = WebhookReply(ctx, "sendMessage", &SendMessageParams{ChatID: update.Message.Chat.ID, Text: "pong"})
+			if err == nil {
+				t.Error("expected error for the second reply")
+			}
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"update_id":1,"message":{"chat":{"id":42},"text":"ping"}}`))
+	w := httptest.NewRecorder()
+
+	b.WebhookHandler()(w, req)
+
+	assertEqualInt(t, w.Code, http.StatusOK)
+	assertEqualString(t, w.Header().Get("Content-Type"), "application/json")
+	assertEqualString(t, w.Body.String(), `{"method":"sendMessage","chat_id":42,"text":"pong"}`)
+}
+
+func TestWebhookReply_NotSyncWebhook(t *testing.T) {
+	err := WebhookReply(context.Background(), "sendMessage", &SendMessageParams{ChatID: 1, Text: "foo"})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
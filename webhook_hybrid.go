@@ -0,0 +1,271 @@
+package bot
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-telegram/bot/models"
+)
+
+const (
+	defaultHybridCheckInterval = time.Minute
+	webhookProbeTimeout        = time.Second * 10
+	webhookProbeHeader         = "X-Bot-Webhook-Probe"
+)
+
+// HybridParams configures StartHybrid
+type HybridParams struct {
+	WebhookServerParams
+
+	// CheckInterval is the interval of GetWebhookInfo checks in webhook mode
+	// and the time of polling before the webhook is set again, by default 1 minute
+	CheckInterval time.Duration
+	// MaxPendingUpdates is the number of pending updates allowed for the webhook with delivery errors.
+	// The webhook is unhealthy if it has a delivery error since the last check and more pending updates
+	MaxPendingUpdates int
+}
+
+// StartHybrid serves the webhook like StartWebhookServer and falls back to getUpdates polling if the webhook is unhealthy.
+// In webhook mode, GetWebhookInfo is checked every CheckInterval. If the webhook has a delivery error since the last check
+// and more than MaxPendingUpdates pending updates, the webhook is deleted and the bot polls updates.
+// After polling for CheckInterval, the public URL is probed with a request carrying the secret token.
+// If the request does not reach the server, the bot keeps polling, otherwise the webhook is set again and checked the same way.
+// Pending updates are not dropped on switch, DropPendingUpdates is used only for the first registration of the webhook.
+// Updates can be delivered twice on switch, so the bot must have the dedup store, see WithDedup
+func (b *Bot) StartHybrid(ctx context.Context, params *HybridParams) error {
+	if b.dedupStore == nil {
+		return fmt.Errorf("error start hybrid mode, the dedup store is required, see WithDedup")
+	}
+
+	s, errServe := b.serveWebhook(&params.WebhookServerParams, b.WebhookHandler())
+	if errServe != nil {
+		return errServe
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		b.StartWebhook(ctx)
+	}()
+
+	errResult := b.runHybrid(ctx, s, params)
+
+	b.shutdownWebhookServer(s, params.ShutdownTimeout)
+
+	cancel()
+	wg.Wait()
+
+	return errResult
+}
+
+func (b *Bot) runHybrid(ctx context.Context, s *webhookServer, params *HybridParams) error {
+	interval := params.CheckInterval
+	if interval <= 0 {
+		interval = defaultHybridCheckInterval
+	}
+
+	for {
+		errRegister := b.registerWebhook(ctx, s.setWebhookParams())
+		// pending updates are dropped only once, later they are delivered to the webhook or polled
+		s.setParams.DropPendingUpdates = false
+
+		if errRegister != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			b.error("error register webhook, switch to polling, %w", errRegister)
+		} else {
+			if b.isDebug {
+				b.debugHandler("webhook mode")
+			}
+
+			if err := b.watchWebhook(ctx, s, interval, params.MaxPendingUpdates); err != nil {
+				return err
+			}
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			if _, err := b.DeleteWebhook(ctx, &DeleteWebhookParams{}); err != nil {
+				b.error("error delete webhook, %w", err)
+			}
+		}
+
+		if b.isDebug {
+			b.debugHandler("polling mode")
+		}
+
+		for {
+			if err := b.pollFor(ctx, s, interval); err != nil {
+				return err
+			}
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			errProbe := b.probeWebhook(ctx, s)
+			if errProbe == nil {
+				break
+			}
+			if ctx.Err() != nil {
+				return nil
+			}
+			b.error("error probe webhook, keep polling, %w", errProbe)
+		}
+
+		b.confirmUpdates(ctx)
+	}
+}
+
+// probeWebhook sends a request to the public webhook URL, which is answered by the server without passing it to handlers.
+// It returns an error if the request does not reach the server, for example the ingress or the TLS proxy is broken
+func (b *Bot) probeWebhook(ctx context.Context, s *webhookServer) error {
+	ctx, cancel := context.WithTimeout(ctx, webhookProbeTimeout)
+	defer cancel()
+
+	req, errReq := http.NewRequestWithContext(ctx, http.MethodPost, s.setParams.URL, strings.NewReader("{}"))
+	if errReq != nil {
+		return errReq
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Telegram-Bot-Api-Secret-Token", s.setParams.SecretToken)
+	req.Header.Set(webhookProbeHeader, s.probeToken)
+
+	client := &http.Client{}
+	if s.certPEM != nil {
+		// the self-signed certificate is trusted by Telegram after SetWebhook, and by the probe
+		roots := x509.NewCertPool()
+		roots.AppendCertsFromPEM(s.certPEM)
+		client.Transport = &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}}
+	}
+
+	resp, errDo := client.Do(req)
+	if errDo != nil {
+		return errDo
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, int64(len(s.probeToken))+1))
+	if resp.StatusCode != http.StatusOK || string(body) != s.probeToken {
+		return fmt.Errorf("the probe request is answered with status %d not by the webhook server", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// probeHandler answers probe requests of probeWebhook and passes other requests to next
+func (s *webhookServer) probeHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		token := req.Header.Get(webhookProbeHeader)
+		if token == "" {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.probeToken)) != 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(s.probeToken))
+	})
+}
+
+// watchWebhook checks GetWebhookInfo every interval, returns nil if the webhook is unhealthy or ctx is done
+func (b *Bot) watchWebhook(ctx context.Context, s *webhookServer, interval time.Duration, maxPending int) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	since := time.Now()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-s.errServe:
+			return fmt.Errorf("error serve webhook, %w", err)
+		case <-ticker.C:
+		}
+
+		checkTime := time.Now()
+
+		info, errInfo := b.GetWebhookInfo(ctx)
+		if errInfo != nil {
+			b.error("error get webhook info, %w", errInfo)
+			continue
+		}
+
+		if webhookUnhealthy(info, s.setParams.URL, since, maxPending) {
+			b.error("webhook is unhealthy, last error %q, pending updates %d, switch to polling", info.LastErrorMessage, info.PendingUpdateCount)
+			return nil
+		}
+
+		since = checkTime
+	}
+}
+
+// webhookUnhealthy returns true if the webhook is not set to url,
+// or it has a delivery error since the time and more than maxPending pending updates
+func webhookUnhealthy(info *models.WebhookInfo, url string, since time.Time, maxPending int) bool {
+	if info.URL != url {
+		return true
+	}
+
+	return info.LastErrorDate > 0 && int64(info.LastErrorDate) >= since.Unix() && info.PendingUpdateCount > maxPending
+}
+
+// pollFor polls updates for the duration, returns nil after the duration or if ctx is done
+func (b *Bot) pollFor(ctx context.Context, s *webhookServer, d time.Duration) error {
+	pollCtx, cancelPoll := context.WithCancel(ctx)
+	pollDone := make(chan struct{})
+	go func() {
+		defer close(pollDone)
+		b.pollUpdates(pollCtx)
+	}()
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	var err error
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	case errServe := <-s.errServe:
+		err = fmt.Errorf("error serve webhook, %w", errServe)
+	}
+
+	cancelPoll()
+	<-pollDone
+
+	return err
+}
+
+// confirmUpdates confirms updates received by polling, so they are not delivered again to the webhook
+func (b *Bot) confirmUpdates(ctx context.Context) {
+	lastUpdateID := atomic.LoadInt64(&b.lastUpdateID)
+	if lastUpdateID == 0 {
+		return
+	}
+
+	params := &getUpdatesParams{
+		Offset: lastUpdateID + 1,
+		Limit:  1,
+	}
+
+	var updates []*models.Update
+	if err := b.rawRequest(ctx, "getUpdates", params, &updates); err != nil {
+		b.error("error confirm updates, %w", err)
+	}
+}
@@ -0,0 +1,226 @@
+package bot
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-telegram/bot/models"
+)
+
+type hybridAPIMock struct {
+	s  *httptest.Server
+	mx sync.Mutex
+
+	url             string
+	setCalls        int
+	dropPending     []string
+	deleteCalls     int
+	unhealthyChecks int
+	pending         []string
+}
+
+func newHybridAPIMock() *hybridAPIMock {
+	m := &hybridAPIMock{}
+	m.s = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		m.mx.Lock()
+		defer m.mx.Unlock()
+
+		switch req.URL.Path {
+		case "/botXXX/setWebhook":
+			fields, _ := requestFields(req)
+			m.url = fields["url"]
+			m.setCalls++
+			m.dropPending = append(m.dropPending, fields["drop_pending_updates"])
+			_, _ = rw.Write([]byte(`{"ok":true,"result":true}`))
+		case "/botXXX/getWebhookInfo":
+			info := `{"url":"` + m.url + `"`
+			if m.unhealthyChecks > 0 {
+				m.unhealthyChecks--
+				info += `,"pending_update_count":1,"last_error_date":` + strconv.FormatInt(time.Now().Unix()+1, 10) + `,"last_error_message":"Connection refused"`
+			}
+			_, _ = rw.Write([]byte(`{"ok":true,"result":` + info + `}}`))
+		case "/botXXX/deleteWebhook":
+			m.url = ""
+			m.deleteCalls++
+			_, _ = rw.Write([]byte(`{"ok":true,"result":true}`))
+		case "/botXXX/getUpdates":
+			if m.url != "" {
+				_, _ = rw.Write([]byte(`{"ok":false,"error_code":409,"description":"Conflict: can't use getUpdates method while webhook is active"}`))
+				return
+			}
+			result := "[" + strings.Join(m.pending, ",") + "]"
+			m.pending = nil
+			_, _ = rw.Write([]byte(`{"ok":true,"result":` + result + `}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return m
+}
+
+func TestWebhookUnhealthy(t *testing.T) {
+	now := time.Unix(1000, 0)
+
+	assertTrue(t, webhookUnhealthy(&models.WebhookInfo{URL: ""}, "https://example.com", now, 0))
+	assertTrue(t, !webhookUnhealthy(&models.WebhookInfo{URL: "https://example.com"}, "https://example.com", now, 0))
+	assertTrue(t, !webhookUnhealthy(&models.WebhookInfo{URL: "https://example.com", LastErrorDate: 999, PendingUpdateCount: 10}, "https://example.com", now, 0))
+	assertTrue(t, !webhookUnhealthy(&models.WebhookInfo{URL: "https://example.com", LastErrorDate: 1001, PendingUpdateCount: 10}, "https://example.com", now, 10))
+	assertTrue(t, webhookUnhealthy(&models.WebhookInfo{URL: "https://example.com", LastErrorDate: 1001, PendingUpdateCount: 11}, "https://example.com", now, 10))
+}
+
+func TestBot_StartHybrid(t *testing.T) {
+	api := newHybridAPIMock()
+	defer api.s.Close()
+
+	api.unhealthyChecks = 2 // the first check is done by registerWebhook
+	api.pending = []string{`{"update_id":5,"message":{"text":"polled"}}`}
+
+	updates := make(chan *models.Update, 10)
+
+	b, err := New("XXX", WithServerURL(api.s.URL), WithSkipGetMe(), WithErrorsHandler(func(err error) {}),
+		WithDedup(NewMemoryDedupStore(100)),
+		WithHTTPClient(time.Second*2, &http.Client{Timeout: time.Second * 2}),
+		WithDefaultHandler(func(_ context.Context, _ *Bot, update *models.Update) {
+			updates <- update
+		}))
+	assertNoErr(t, err)
+
+	addr := freeAddr(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errHybrid := make(chan error, 1)
+	go func() {
+		errHybrid <- b.StartHybrid(ctx, &HybridParams{
+			WebhookServerParams: WebhookServerParams{
+				Addr:               addr,
+				URL:                "http://" + addr + "/hook",
+				DropPendingUpdates: true,
+			},
+			CheckInterval: time.Millisecond * 100,
+		})
+	}()
+
+	select {
+	case upd := <-updates:
+		assertEqualInt(t, int(upd.ID), 5)
+	case <-time.After(time.Second * 5):
+		t.Fatal("update is not polled after webhook fallback")
+	}
+
+	deadline := time.Now().Add(time.Second * 5)
+	for {
+		api.mx.Lock()
+		setCalls := api.setCalls
+		api.mx.Unlock()
+		if setCalls >= 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("webhook is not set again")
+		}
+		time.Sleep(time.Millisecond * 20)
+	}
+
+	cancel()
+
+	select {
+	case err = <-errHybrid:
+		assertNoErr(t, err)
+	case <-time.After(time.Second * 5):
+		t.Fatal("hybrid runner is not stopped")
+	}
+
+	api.mx.Lock()
+	defer api.mx.Unlock()
+
+	assertTrue(t, api.deleteCalls >= 2)
+	// pending updates are dropped only on the first registration
+	assertEqualString(t, api.dropPending[0], "true")
+	for _, drop := range api.dropPending[1:] {
+		assertEqualString(t, drop, "")
+	}
+}
+
+func TestBot_StartHybrid_NoDedup(t *testing.T) {
+	b, err := New("XXX", WithSkipGetMe())
+	assertNoErr(t, err)
+
+	err = b.StartHybrid(context.Background(), &HybridParams{WebhookServerParams: WebhookServerParams{Addr: freeAddr(t), URL: "http://localhost/hook"}})
+	if err == nil {
+		t.Fatal("expected error without dedup store")
+	}
+}
+
+func TestBot_StartHybrid_BrokenIngress(t *testing.T) {
+	api := newHybridAPIMock()
+	defer api.s.Close()
+
+	api.unhealthyChecks = 2
+
+	b, err := New("XXX", WithServerURL(api.s.URL), WithSkipGetMe(), WithErrorsHandler(func(err error) {}),
+		WithDedup(NewMemoryDedupStore(100)),
+		WithHTTPClient(time.Second*2, &http.Client{Timeout: time.Second * 2}),
+		WithDefaultHandler(func(_ context.Context, _ *Bot, _ *models.Update) {}))
+	assertNoErr(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errHybrid := make(chan error, 1)
+	go func() {
+		errHybrid <- b.StartHybrid(ctx, &HybridParams{
+			WebhookServerParams: WebhookServerParams{
+				Addr: freeAddr(t),
+				// the public url does not lead to the server
+				URL: "http://" + freeAddr(t) + "/hook",
+			},
+			CheckInterval: time.Millisecond * 100,
+		})
+	}()
+
+	time.Sleep(time.Millisecond * 800)
+	cancel()
+
+	select {
+	case err = <-errHybrid:
+		assertNoErr(t, err)
+	case <-time.After(time.Second * 5):
+		t.Fatal("hybrid runner is not stopped")
+	}
+
+	api.mx.Lock()
+	defer api.mx.Unlock()
+
+	// the webhook is not set again while the probe fails
+	// the webhook is deleted on fallback and on shutdown
+	assertEqualInt(t, api.deleteCalls, 2)
+	assertEqualInt(t, api.setCalls, 1)
+}
+
+func TestWebhookServer_ProbeHandler(t *testing.T) {
+	s := &webhookServer{probeToken: "token"}
+	var handled int
+	h := s.probeHandler(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) { handled++ }))
+
+	req := httptest.NewRequest(http.MethodPost, "/hook", nil)
+	req.Header.Set(webhookProbeHeader, "token")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assertEqualString(t, rec.Body.String(), "token")
+
+	req.Header.Set(webhookProbeHeader, "wrong")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assertEqualInt(t, rec.Code, http.StatusUnauthorized)
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/hook", nil))
+	assertEqualInt(t, handled, 1)
+}
@@ -0,0 +1,300 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-telegram/bot/models"
+)
+
+const (
+	defaultWebhookShutdownTimeout = time.Second * 10
+	webhookReadHeaderTimeout      = time.Second * 10
+)
+
+// WebhookServerParams configures the webhook server started by StartWebhookServer
+type WebhookServerParams struct {
+	// Addr is the TCP address to listen on, for example ":8443"
+	Addr string
+	// URL is the public HTTPS url of the webhook registered with SetWebhook. The handler is served on its path
+	URL string
+
+	// CertFile and KeyFile are paths to the TLS certificate and key.
+	// If both are empty and SelfSigned is false, the server serves plain HTTP, for example behind a TLS proxy
+	CertFile string
+	KeyFile  string
+	// UploadCertificate uploads CertFile with SetWebhook, required for self-signed certificates
+	UploadCertificate bool
+	// SelfSigned generates a self-signed certificate for the URL host and uploads it with SetWebhook
+	SelfSigned bool
+
+	IPAddress          string
+	MaxConnections     int
+	DropPendingUpdates bool
+
+	// ShutdownTimeout limits the graceful shutdown of the server, by default 10 seconds
+	ShutdownTimeout time.Duration
+}
+
+// StartWebhookServer serves WebhookHandler, registers the webhook and starts the Bot with webhook mode.
+// If the bot has no webhook secret token, a random token is generated.
+// AllowedUpdates are taken from WithAllowedUpdates option.
+// The registration is verified with GetWebhookInfo.
+// When ctx is done, the server is shut down and the webhook is deleted
+func (b *Bot) StartWebhookServer(ctx context.Context, params *WebhookServerParams) error {
+	s, errServe := b.serveWebhook(params, b.WebhookHandler())
+	if errServe != nil {
+		return errServe
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		b.StartWebhook(ctx)
+	}()
+
+	errResult := b.registerWebhook(ctx, s.setWebhookParams())
+	if errResult == nil {
+		select {
+		case <-ctx.Done():
+		case err := <-s.errServe:
+			errResult = fmt.Errorf("error serve webhook, %w", err)
+		}
+	}
+
+	b.shutdownWebhookServer(s, params.ShutdownTimeout)
+
+	cancel()
+	wg.Wait()
+
+	return errResult
+}
+
+// webhookServer is the webhook http server started by serveWebhook
+type webhookServer struct {
+	srv       *http.Server
+	errServe  chan error
+	setParams SetWebhookParams
+	// certPEM is the certificate to upload with SetWebhook, if any
+	certPEM []byte
+	// probeToken answers probe requests of the server to its public url, see probeWebhook
+	probeToken string
+}
+
+// setWebhookParams returns SetWebhook params with a new reader of the certificate to upload
+func (s *webhookServer) setWebhookParams() *SetWebhookParams {
+	params := s.setParams
+	if s.certPEM != nil {
+		params.Certificate = &models.InputFileUpload{Filename: "cert.pem", Data: bytes.NewReader(s.certPEM)}
+	}
+	return &params
+}
+
+// serveWebhook starts the http server with the handler on the webhook url path.
+// If the bot has no webhook secret token, a random token is generated
+func (b *Bot) serveWebhook(params *WebhookServerParams, handler http.Handler) (*webhookServer, error) {
+	u, errParse := url.Parse(params.URL)
+	if errParse != nil {
+		return nil, fmt.Errorf("error parse webhook url, %w", errParse)
+	}
+
+	if b.webhookSecretToken == "" {
+		secret, errSecret := generateWebhookSecretToken()
+		if errSecret != nil {
+			return nil, fmt.Errorf("error generate webhook secret token, %w", errSecret)
+		}
+		b.webhookSecretToken = secret
+	}
+
+	s := &webhookServer{
+		errServe: make(chan error, 1),
+		setParams: SetWebhookParams{
+			URL:                params.URL,
+			IPAddress:          params.IPAddress,
+			MaxConnections:     params.MaxConnections,
+			AllowedUpdates:     b.allowedUpdates,
+			DropPendingUpdates: params.DropPendingUpdates,
+			SecretToken:        b.webhookSecretToken,
+		},
+	}
+
+	probeToken, errProbeToken := generateWebhookSecretToken()
+	if errProbeToken != nil {
+		return nil, fmt.Errorf("error generate webhook probe token, %w", errProbeToken)
+	}
+	s.probeToken = probeToken
+
+	tlsConfig, certPEM, errTLS := webhookTLSConfig(params, u.Hostname())
+	if errTLS != nil {
+		return nil, errTLS
+	}
+	s.certPEM = certPEM
+
+	ln, errListen := net.Listen("tcp", params.Addr)
+	if errListen != nil {
+		return nil, fmt.Errorf("error listen %s, %w", params.Addr, errListen)
+	}
+	if tlsConfig != nil {
+		ln = tls.NewListener(ln, tlsConfig)
+	}
+
+	path := u.Path
+	if path == "" {
+		path = "/"
+	}
+	mux := http.NewServeMux()
+	mux.Handle(path, s.probeHandler(handler))
+
+	s.srv = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: webhookReadHeaderTimeout,
+	}
+
+	go func() {
+		s.errServe <- s.srv.Serve(ln)
+	}()
+
+	return s, nil
+}
+
+// shutdownWebhookServer stops the webhook server and deletes the webhook
+func (b *Bot) shutdownWebhookServer(s *webhookServer, timeout time.Duration) {
+	if timeout == 0 {
+		timeout = defaultWebhookShutdownTimeout
+	}
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), timeout)
+	defer shutdownCancel()
+
+	if err := s.srv.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		b.error("error shutdown webhook server, %w", err)
+	}
+
+	if _, err := b.DeleteWebhook(shutdownCtx, &DeleteWebhookParams{}); err != nil {
+		b.error("error delete webhook, %w", err)
+	}
+}
+
+// registerWebhook calls SetWebhook and checks the result with GetWebhookInfo
+func (b *Bot) registerWebhook(ctx context.Context, params *SetWebhookParams) error {
+	if _, err := b.SetWebhook(ctx, params); err != nil {
+		return fmt.Errorf("error set webhook, %w", err)
+	}
+
+	info, errInfo := b.GetWebhookInfo(ctx)
+	if errInfo != nil {
+		return fmt.Errorf("error get webhook info, %w", errInfo)
+	}
+
+	if info.URL != params.URL {
+		return fmt.Errorf("error set webhook, registered url %q, expected %q", info.URL, params.URL)
+	}
+
+	if params.Certificate != nil && !info.HasCustomCertificate {
+		return fmt.Errorf("error set webhook, custom certificate is not registered")
+	}
+
+	return nil
+}
+
+// webhookTLSConfig returns TLS config of the webhook server or nil for plain HTTP,
+// and the certificate to upload with SetWebhook
+func webhookTLSConfig(params *WebhookServerParams, host string) (*tls.Config, []byte, error) {
+	if params.SelfSigned {
+		certPEM, keyPEM, errGenerate := generateSelfSignedCertificate(host)
+		if errGenerate != nil {
+			return nil, nil, fmt.Errorf("error generate self-signed certificate, %w", errGenerate)
+		}
+		cert, errPair := tls.X509KeyPair(certPEM, keyPEM)
+		if errPair != nil {
+			return nil, nil, fmt.Errorf("error load self-signed certificate, %w", errPair)
+		}
+		return &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}, certPEM, nil
+	}
+
+	if params.CertFile == "" && params.KeyFile == "" {
+		return nil, nil, nil
+	}
+
+	cert, errLoad := tls.LoadX509KeyPair(params.CertFile, params.KeyFile)
+	if errLoad != nil {
+		return nil, nil, fmt.Errorf("error load certificate, %w", errLoad)
+	}
+
+	var certPEM []byte
+	if params.UploadCertificate {
+		var errRead error
+		certPEM, errRead = os.ReadFile(params.CertFile)
+		if errRead != nil {
+			return nil, nil, fmt.Errorf("error read certificate, %w", errRead)
+		}
+	}
+
+	return &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}, certPEM, nil
+}
+
+// generateSelfSignedCertificate returns PEM encoded certificate and key for the host
+func generateSelfSignedCertificate(host string) ([]byte, []byte, error) {
+	key, errKey := rsa.GenerateKey(rand.Reader, 2048)
+	if errKey != nil {
+		return nil, nil, errKey
+	}
+
+	serial, errSerial := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if errSerial != nil {
+		return nil, nil, errSerial
+	}
+
+	template := x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: host},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().AddDate(1, 0, 0),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		template.IPAddresses = []net.IP{ip}
+	} else {
+		template.DNSNames = []string{host}
+	}
+
+	der, errCreate := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if errCreate != nil {
+		return nil, nil, errCreate
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	return certPEM, keyPEM, nil
+}
+
+// generateWebhookSecretToken returns random token of allowed characters A-Z, a-z, 0-9
+func generateWebhookSecretToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
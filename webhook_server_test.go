@@ -0,0 +1,161 @@
+package bot
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-telegram/bot/models"
+)
+
+type webhookAPIMock struct {
+	s   *httptest.Server
+	mx  sync.Mutex
+	set map[string]string
+	url string
+
+	hasCertificate bool
+	deleteCalls    int
+}
+
+func newWebhookAPIMock() *webhookAPIMock {
+	m := &webhookAPIMock{}
+	m.s = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		m.mx.Lock()
+		defer m.mx.Unlock()
+
+		switch req.URL.Path {
+		case "/botXXX/setWebhook":
+			m.set, _ = requestFields(req)
+			if _, _, err := req.FormFile("certificate"); err == nil {
+				m.hasCertificate = true
+			}
+			m.url = m.set["url"]
+			_, _ = rw.Write([]byte(`{"ok":true,"result":true}`))
+		case "/botXXX/getWebhookInfo":
+			_, _ = rw.Write([]byte(`{"ok":true,"result":{"url":"` + m.url + `","has_custom_certificate":` + map[bool]string{true: "true", false: "false"}[m.hasCertificate] + `}}`))
+		case "/botXXX/deleteWebhook":
+			m.deleteCalls++
+			m.url = ""
+			_, _ = rw.Write([]byte(`{"ok":true,"result":true}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return m
+}
+
+func freeAddr(t *testing.T) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	_ = ln.Close()
+	return addr
+}
+
+func TestBot_StartWebhookServer(t *testing.T) {
+	api := newWebhookAPIMock()
+	defer api.s.Close()
+
+	updates := make(chan *models.Update, 1)
+
+	b, err := New("XXX", WithServerURL(api.s.URL), WithSkipGetMe(), WithAllowedUpdates(AllowedUpdates{"message"}),
+		WithDefaultHandler(func(_ context.Context, _ *Bot, update *models.Update) {
+			updates <- update
+		}))
+	assertNoErr(t, err)
+
+	addr := freeAddr(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errServer := make(chan error, 1)
+	go func() {
+		errServer <- b.StartWebhookServer(ctx, &WebhookServerParams{
+			Addr:       addr,
+			URL:        "https://127.0.0.1/hook",
+			SelfSigned: true,
+		})
+	}()
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}} //nolint:gosec
+
+	var resp *http.Response
+	for i := 0; i < 50; i++ {
+		api.mx.Lock()
+		registered := api.url != ""
+		api.mx.Unlock()
+		if registered {
+			req, _ := http.NewRequest(http.MethodPost, "https://"+addr+"/hook", strings.NewReader(`{"update_id":1}`))
+			req.Header.Set("X-Telegram-Bot-Api-Secret-Token", b.webhookSecretToken)
+			resp, err = client.Do(req)
+			if err == nil {
+				_ = resp.Body.Close()
+				break
+			}
+		}
+		time.Sleep(time.Millisecond * 50)
+	}
+	if resp == nil {
+		t.Fatalf("webhook server is not available, %v", err)
+	}
+
+	select {
+	case upd := <-updates:
+		if upd.ID != 1 {
+			t.Fatalf("unexpected update id %d", upd.ID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("update is not processed")
+	}
+
+	cancel()
+
+	select {
+	case err = <-errServer:
+		assertNoErr(t, err)
+	case <-time.After(time.Second * 5):
+		t.Fatal("webhook server is not stopped")
+	}
+
+	api.mx.Lock()
+	defer api.mx.Unlock()
+
+	assertEqualString(t, api.set["url"], "https://127.0.0.1/hook")
+	assertEqualString(t, api.set["allowed_updates"], `["message"]`)
+	assertEqualInt(t, len(api.set["secret_token"]), 64)
+	assertTrue(t, api.hasCertificate)
+	assertEqualInt(t, api.deleteCalls, 1)
+}
+
+func TestBot_StartWebhookServer_VerifyError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/botXXX/getWebhookInfo":
+			_, _ = rw.Write([]byte(`{"ok":true,"result":{"url":""}}`))
+		default:
+			_, _ = rw.Write([]byte(`{"ok":true,"result":true}`))
+		}
+	}))
+	defer s.Close()
+
+	b, err := New("XXX", WithServerURL(s.URL), WithSkipGetMe())
+	assertNoErr(t, err)
+
+	err = b.StartWebhookServer(context.Background(), &WebhookServerParams{
+		Addr: freeAddr(t),
+		URL:  "https://example.com/hook",
+	})
+	if err == nil || !strings.Contains(err.Error(), "registered url") {
+		t.Fatalf("unexpected error %v", err)
+	}
+}